@@ -17,6 +17,7 @@ import (
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
 	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -26,14 +27,19 @@ const (
 	shortMessageDelay    = 1250 * time.Millisecond
 	standardMessageDelay = 2 * time.Second
 	longMessageDelay     = 3 * time.Second
+	scanRefreshDelay     = 1 * time.Second
+	rotateCommand        = "rotate"
 )
 
 func init() {
-	gaba.InitSDL(gaba.Options{
-		WindowTitle:    "Aesthetics",
-		ShowBackground: true,
-		LogFilename:    "aesthetics.log",
-	})
+	// The rotate command runs from the boot script with nothing on screen
+	if !isRotateCommand() {
+		gaba.InitSDL(gaba.Options{
+			WindowTitle:    "Aesthetics",
+			ShowBackground: true,
+			LogFilename:    "aesthetics.log",
+		})
+	}
 
 	common.SetLogLevel(defaultLogLevel)
 	common.InitIncludes()
@@ -44,6 +50,7 @@ func init() {
 	}
 
 	common.SetLogLevel(config.LogLevel)
+	utils.SetUserTraversalRules(config.TraversalRules)
 	state.SetConfig(config)
 
 	logger := common.GetLoggerInstance()
@@ -65,6 +72,9 @@ func loadConfig() (*models.Config, error) {
 }
 
 func main() {
+	if isRotateCommand() {
+		os.Exit(runThemeRotation())
+	}
 	defer cleanup()
 
 	logger := common.GetLoggerInstance()
@@ -80,24 +90,66 @@ func cleanup() {
 	common.CloseLogger()
 }
 
+func isRotateCommand() bool {
+	return len(os.Args) > 1 && os.Args[1] == rotateCommand
+}
+
+// runThemeRotation applies the next theme in the rotation for the boot script and returns the process exit code
+func runThemeRotation() int {
+	defer common.CloseLogger()
+	logger := common.GetLoggerInstance()
+	logger.Info("Rotating theme")
+
+	message, err := utils.RunThemeRotation(utils.GetDecorationSources(state.GetAppState().Config), time.Now())
+	if message != "" {
+		fmt.Println(message)
+	}
+	if err != nil {
+		logger.Error("Theme rotation failed", zap.Error(err))
+		fmt.Println("Theme rotation failed: " + err.Error())
+		return 1
+	}
+	return 0
+}
+
 func runApplicationLoop() {
 	var screen models.Screen
 	screen = ui.InitMainMenu()
 	state.AddNewMenuPosition()
 
 	for {
+		screen = ensureDecorationAggregations(screen)
 		result, code, _ := screen.Draw() // TODO: Implement proper error handling
 		screen = handleScreenTransition(screen, result, code)
 	}
 }
 
+// searchableScreen is any list that filters itself by a stored search query
+type searchableScreen interface {
+	SearchKey() string
+}
+
 func handleScreenTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	if searchable, isSearchable := currentScreen.(searchableScreen); isSearchable && code == utils.ExitCodeSearch {
+		promptSearchQuery(searchable.SearchKey())
+		return currentScreen
+	}
 	switch currentScreen.Name() {
 		case models.ScreenNames.MainMenu:
 			return handleMainMenuTransition(result, code)
 		case models.ScreenNames.Settings:
+			if code == utils.ExitCodeAction {
+				state.AddNewMenuPosition()
+				return ui.InitDecorationSources()
+			}
 			state.ReturnToMain()
 			return ui.InitMainMenu()
+		case models.ScreenNames.DecorationSources:
+			return handleDecorationSourcesTransition(result, code)
+		case models.ScreenNames.DecorationSourceOptions:
+			return handleDecorationSourceOptionsTransition(currentScreen, result, code)
+		case models.ScreenNames.DecorationSourcePicker:
+			return handleDecorationSourcePickerTransition(currentScreen, result, code)
 		case models.ScreenNames.DownloadThemesBrowser:
 			return handleDownloadThemesBrowserTransition(currentScreen, result, code)
 		case models.ScreenNames.DownloadThemeConfirmation:
@@ -110,12 +162,70 @@ func handleScreenTransition(currentScreen models.Screen, result interface{}, cod
 			return handleManageThemeComponentsTransition(currentScreen, result, code)
 		case models.ScreenNames.ManageThemeComponentOptions:
 			return handleManageThemeComponentOptionsTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeTargetPicker:
+			return handleThemeTargetPickerTransition(currentScreen, result, code)
+		case models.ScreenNames.RecolorTheme:
+			return handleRecolorThemeTransition(currentScreen, result, code)
+		case models.ScreenNames.AestheticTools:
+			return handleAestheticToolsTransition(result, code)
+		case models.ScreenNames.CoverageReport:
+			return handleCoverageReportTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeCompareBase:
+			return handleThemeCompareBaseTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeComparison:
+			return handleThemeComparisonTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeComparisonDetails:
+			return handleThemeComparisonDetailsTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeComposer:
+			return handleThemeComposerTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeEditor:
+			return handleThemeEditorTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeSlotOptions:
+			return handleThemeSlotOptionsTransition(currentScreen, result, code)
+		case models.ScreenNames.ComposeSourcePicker:
+			return handleComposeSourcePickerTransition(currentScreen, result, code)
+		case models.ScreenNames.ComposeConsolePicker:
+			return handleComposeConsolePickerTransition(currentScreen, result, code)
+		case models.ScreenNames.CollectionCollage:
+			return handleCollectionCollageTransition(result, code)
 		case models.ScreenNames.DirectoryBrowser:
 			return handleDirectoryBrowserTransition(currentScreen, result, code)
 		case models.ScreenNames.DecorationOptions:
 			return handleDecorationOptionsTransition(currentScreen, result, code)
 		case models.ScreenNames.DecorationBrowser:
 			return handleDecorationBrowserTransition(currentScreen, result, code)
+		case models.ScreenNames.DecorationActions:
+			return handleDecorationActionsTransition(currentScreen, result, code)
+		case models.ScreenNames.AddToThemeSelection:
+			return handleAddToThemeSelectionTransition(currentScreen, result, code)
+		case models.ScreenNames.AddToThemePicker:
+			return handleAddToThemePickerTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeSlotPicker:
+			return handleThemeSlotPickerTransition(currentScreen, result, code)
+		case models.ScreenNames.BulkApplyMode:
+			return handleBulkApplyModeTransition(currentScreen, result, code)
+		case models.ScreenNames.BulkTargetPicker:
+			return handleBulkTargetPickerTransition(currentScreen, result, code)
+		case models.ScreenNames.AutoDecorate:
+			return handleAutoDecorateTransition(result, code)
+		case models.ScreenNames.AutoDecoratePlan:
+			return handleAutoDecoratePlanTransition(currentScreen, result, code)
+		case models.ScreenNames.ShuffleDecorations:
+			return handleShuffleDecorationsTransition(currentScreen, result, code)
+		case models.ScreenNames.ShuffleConsolePicker:
+			return handleShuffleConsolePickerTransition(currentScreen, result, code)
+		case models.ScreenNames.ThemeRotation:
+			return handleThemeRotationTransition(result, code)
+		case models.ScreenNames.RotationThemePicker:
+			return handleRotationThemePickerTransition(result, code)
+		case models.ScreenNames.RotationEntryOptions:
+			return handleRotationEntryOptionsTransition(currentScreen, result, code)
+		case models.ScreenNames.Profiles:
+			return handleProfilesTransition(result, code)
+		case models.ScreenNames.ProfileOptions:
+			return handleProfileOptionsTransition(currentScreen, result, code)
+		case models.ScreenNames.CropDecoration:
+			return handleCropDecorationTransition(currentScreen, result, code)
 		default:
 			state.ReturnToMain()
 			return ui.InitMainMenu()
@@ -141,6 +251,10 @@ func handleMainMenuTransition(result interface{}, code int) models.Screen {
 					return ui.InitManageThemes()
 				case ui.ManageCurrentThemeDisplayName:
 					return ui.InitManageThemeComponents(models.Theme{})
+				case ui.AestheticToolsDisplayName:
+					return ui.InitAestheticTools()
+				case ui.ProfilesDisplayName:
+					return ui.InitProfiles()
 			}
 		case utils.ExitCodeAction:
 			return ui.InitSettingsScreen()
@@ -165,6 +279,399 @@ func handleManageThemesTransition(result interface{}, code int) models.Screen {
 	return ui.InitMainMenu()
 }
 
+func handleAestheticToolsTransition(result interface{}, code int) models.Screen {
+	switch code {
+		case utils.ExitCodeSelect:
+			switch result.(string) {
+				case ui.CollectionCollageDisplayName:
+					return ui.InitCollectionCollage()
+				case ui.AutoDecorateDisplayName:
+					return ui.InitAutoDecorate()
+				case ui.ShuffleDisplayName:
+					return ui.InitShuffleDecorations()
+				case ui.ThemeRotationDisplayName:
+					state.AddNewMenuPosition()
+					return ui.InitThemeRotation()
+				case ui.CoverageReportDisplayName:
+					state.AddNewMenuPosition()
+					return ui.InitCoverageReport(models.Theme{})
+				case ui.ComposeDisplayName:
+					state.AddNewMenuPosition()
+					return ui.InitThemeComposer(models.ThemeRecipe{})
+				case ui.NewThemeDisplayName:
+					theme, err := utils.CreateEmptyTheme()
+					if err != nil {
+						utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+						return ui.InitAestheticTools()
+					}
+					state.AddNewMenuPosition()
+					editor := ui.InitThemeEditor(theme, nil)
+					editor.FromTools = true
+					return editor
+			}
+	}
+	state.ReturnToMain()
+	return ui.InitMainMenu()
+}
+
+func handleCollectionCollageTransition(result interface{}, code int) models.Screen {
+	switch code {
+		case utils.ExitCodeSelect:
+			selections := result.(models.CollageSelections)
+			res, err := gaba.ProcessMessage("Building collection icons", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+				return utils.GenerateCollectionCollages(selections)
+			})
+			if err != nil {
+				utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+			} else {
+				utils.ShowTimedMessage(strconv.Itoa(res.Result.(int)) + " collection icons generated", shortMessageDelay)
+				state.ClearDecorationAggregations()
+			}
+	}
+	return ui.InitAestheticTools()
+}
+
+func handleDecorationSourcesTransition(result interface{}, code int) models.Screen {
+	switch code {
+		case utils.ExitCodeSelect:
+			sourceIndex := result.(int)
+			if sourceIndex == ui.AddDecorationSourceIndex {
+				state.AddNewMenuPosition()
+				return ui.InitDecorationSourcePicker(utils.SDCardDirectory)
+			}
+			sources := utils.GetDecorationSources(state.GetAppState().Config)
+			return ui.InitDecorationSourceOptions(sourceIndex, sources[sourceIndex])
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitSettingsScreen()
+}
+
+func handleDecorationSourceOptionsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	dso := currentScreen.(ui.DecorationSourceOptions)
+	switch code {
+		case utils.ExitCodeSelect:
+			selections := result.(models.DecorationSourceSelections)
+			sources := utils.GetDecorationSources(state.GetAppState().Config)
+			if selections.Remove {
+				if utils.ConfirmAction("Stop scanning this folder for decorations?\n" + splitPathToLines(dso.Source.Path), "") {
+					sources = append(sources[:dso.SourceIndex], sources[dso.SourceIndex+1:]...)
+					state.UpdateCurrentMenuPosition(0, 0)
+					saveDecorationSources(sources)
+				}
+				return ui.InitDecorationSources()
+			}
+			source := dso.Source
+			source.TagMode = selections.TagMode
+			switch selections.ExclusionAction {
+				case utils.ExclusionsAdd:
+					if pattern := promptExclusionPattern(); pattern != "" {
+						source.Exclusions = append(source.Exclusions, pattern)
+					}
+				case utils.ExclusionsClear:
+					source.Exclusions = nil
+			}
+			sources[dso.SourceIndex] = source
+			saveDecorationSources(sources)
+			return ui.InitDecorationSourceOptions(dso.SourceIndex, source)
+	}
+	return ui.InitDecorationSources()
+}
+
+func handleDecorationSourcePickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	dsp := currentScreen.(ui.DecorationSourcePicker)
+	pickerDepth := strings.Count(strings.TrimPrefix(dsp.CurrentPath, utils.SDCardDirectory), "/") + 1
+	switch code {
+		case utils.ExitCodeSelect:
+			state.AddNewMenuPosition()
+			return ui.InitDecorationSourcePicker(result.(string))
+		case utils.ExitCodeAction:
+			sourcePath := result.(string)
+			sources := utils.GetDecorationSources(state.GetAppState().Config)
+			if overlappingSource, overlaps := utils.FindOverlappingDecorationSource(sources, sourcePath); overlaps {
+				if overlappingSource.Path == sourcePath {
+					utils.ShowTimedMessage(sourcePath + "\nis already a decoration source", shortMessageDelay)
+				} else {
+					utils.ShowTimedMessage("Overlaps the decoration source:\n" + splitPathToLines(overlappingSource.Path), standardMessageDelay)
+				}
+				return ui.InitDecorationSourcePicker(dsp.CurrentPath)
+			}
+			sources = append(sources, models.DecorationSource{Path: sourcePath, TagMode: utils.SourceTagsFromDirectories})
+			saveDecorationSources(sources)
+			utils.ShowTimedMessage("Added decoration source:\n" + splitPathToLines(sourcePath), shortMessageDelay)
+			state.RemoveMenuPositions(pickerDepth)
+			return ui.InitDecorationSources()
+	}
+	state.RemoveMenuPositions(1)
+	if pickerDepth <= 1 {
+		return ui.InitDecorationSources()
+	}
+	return ui.InitDecorationSourcePicker(filepath.Dir(dsp.CurrentPath))
+}
+
+// promptSearchQuery edits the stored query for a list. Clearing the text clears the search
+func promptSearchQuery(searchKey string) {
+	res, err := gaba.Keyboard(state.GetSearchQuery(searchKey))
+	if err != nil {
+		utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+		return
+	}
+	if !res.IsSome() {
+		return
+	}
+	state.SetSearchQuery(searchKey, strings.TrimSpace(res.Unwrap()))
+	state.UpdateCurrentMenuPosition(0, 0)
+}
+
+// promptExclusionPattern asks for a glob pattern until a valid one is entered or the keyboard is dismissed
+func promptExclusionPattern() string {
+	pattern := ""
+	for {
+		res, err := gaba.Keyboard(pattern)
+		if err != nil {
+			utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+			return ""
+		}
+		if !res.IsSome() || res.Unwrap() == "" {
+			return ""
+		}
+		pattern = res.Unwrap()
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			utils.ShowTimedMessage(pattern + "\nis not a valid pattern.\nTry something like *.bak.png or Arcade/*", standardMessageDelay)
+			continue
+		}
+		return pattern
+	}
+}
+
+// saveDecorationSources stores the edited source list and drops the current aggregations so the next browse rescans
+func saveDecorationSources(sources []models.DecorationSource) {
+	config := state.GetAppState().Config
+	config.DecorationSources = sources
+	if err := utils.SaveConfig(config); err != nil {
+		common.GetLoggerInstance().Error("Error saving config", zap.Error(err))
+		utils.ShowTimedMessage("Unable to save decoration sources!", longMessageDelay)
+	}
+	state.ClearDecorationAggregations()
+}
+
+func handleCoverageReportTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	cr := currentScreen.(ui.CoverageReport)
+	switch code {
+		case utils.ExitCodeSelect:
+			if row, ok := result.(models.CoverageRow); ok {
+				showCoverageDetails(row)
+			}
+			return ui.InitCoverageReport(cr.Theme)
+	}
+	state.RemoveMenuPositions(1)
+	if utils.IsCurrentTheme(cr.Theme) {
+		return ui.InitAestheticTools()
+	}
+	return ui.InitManageThemeOptions(cr.Theme)
+}
+
+func showCoverageDetails(row models.CoverageRow) {
+	message := row.TargetName + " (" + row.TargetGroup + ")"
+	for _, componentType := range utils.CoverageComponentTypes {
+		covered, applicable := row.Coverage[componentType]
+		status := "missing"
+		if !applicable {
+			status = "not used"
+		} else if covered {
+			status = "themed"
+		}
+		message = message + "\n" + componentType + ": " + status
+	}
+	utils.ShowTimedMessage(message, longMessageDelay)
+}
+
+func handleThemeCompareBaseTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	tcb := currentScreen.(ui.ThemeCompareBase)
+	switch code {
+		case utils.ExitCodeSelect:
+			baseTheme := result.(models.Theme)
+			res, err := gaba.ProcessMessage("Comparing " + tcb.Theme.ThemeName, gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+				return utils.CompareThemes(tcb.Theme, baseTheme)
+			})
+			if err != nil {
+				utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+				return ui.InitThemeCompareBase(tcb.Theme)
+			}
+			state.AddNewMenuPosition()
+			return ui.InitThemeComparison(tcb.Theme, baseTheme, res.Result.([]models.ComponentComparison))
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitManageThemeOptions(tcb.Theme)
+}
+
+func handleThemeComparisonTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	tc := currentScreen.(ui.ThemeComparison)
+	switch code {
+		case utils.ExitCodeSelect:
+			state.AddNewMenuPosition()
+			return ui.InitThemeComparisonDetails(tc, result.(models.ComponentComparison))
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitThemeCompareBase(tc.Theme)
+}
+
+func handleThemeComparisonDetailsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	tcd := currentScreen.(ui.ThemeComparisonDetails)
+	switch code {
+		case utils.ExitCodeSelect:
+			comparison := result.(models.DecorationComparison)
+			message := comparison.Status + "\nTarget: " + splitPathToLines(comparison.TargetPath)
+			if comparison.ThemeImagePath != "" {
+				message = message + "\nTheme: " + splitPathToLines(comparison.ThemeImagePath)
+			}
+			if comparison.BaseImagePath != "" {
+				message = message + "\nBase: " + splitPathToLines(comparison.BaseImagePath)
+			}
+			utils.ShowTimedMessage(message, longMessageDelay)
+			return tcd
+	}
+	state.RemoveMenuPositions(1)
+	return tcd.Comparison
+}
+
+func handleThemeComposerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	tc := currentScreen.(ui.ThemeComposer)
+	switch code {
+		case utils.ExitCodeSelect:
+			selection := result.(ui.ComposerSelection)
+			switch selection.Action {
+				case "":
+					state.AddNewMenuPosition()
+					return ui.InitComposeSourcePicker(tc.Recipe, selection.ComponentName, selection.ConsoleTag)
+				case ui.ComposeAddConsoleName:
+					state.AddNewMenuPosition()
+					return ui.InitComposeConsolePicker(tc.Recipe)
+			}
+			composedTheme, composed := composeTheme(tc.Recipe)
+			if !composed {
+				return tc
+			}
+			state.RemoveMenuPositions(1)
+			if tc.Recipe.ThemeName != "" {
+				return ui.InitManageThemeOptions(composedTheme)
+			}
+			return ui.InitAestheticTools()
+	}
+	state.RemoveMenuPositions(1)
+	if tc.Recipe.ThemeName != "" {
+		if theme, exists := utils.GetDownloadedThemes()[tc.Recipe.ThemeName]; exists {
+			return ui.InitManageThemeOptions(theme)
+		}
+		return ui.InitManageThemes()
+	}
+	return ui.InitAestheticTools()
+}
+
+// composeTheme builds or regenerates a composed theme, reporting the outcome. The theme is only valid when composed
+func composeTheme(recipe models.ThemeRecipe) (models.Theme, bool) {
+	message := "Composing theme"
+	if recipe.ThemeName != "" {
+		message = "Regenerating " + recipe.ThemeName
+	}
+	res, err := gaba.ProcessMessage(message, gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		composedTheme, count, err := utils.ComposeTheme(recipe)
+		if err != nil {
+			return count, err
+		}
+		return composedTheme, nil
+	})
+	if err != nil {
+		utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+		return models.Theme{}, false
+	}
+	composedTheme := res.Result.(models.Theme)
+	if recipe.ThemeName != "" {
+		utils.ShowTimedMessage("Regenerated theme: " + composedTheme.ThemeName, shortMessageDelay)
+	} else {
+		utils.ShowTimedMessage("Created theme: " + composedTheme.ThemeName, shortMessageDelay)
+	}
+	state.ClearDecorationAggregations()
+	return composedTheme, true
+}
+
+func handleThemeEditorTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	te := currentScreen.(ui.ThemeEditor)
+	switch code {
+		case utils.ExitCodeSelect:
+			state.AddNewMenuPosition()
+			return ui.InitThemeSlotOptions(te, result.(models.ThemeSlot))
+		case utils.ExitCodeAction:
+			slot := result.(models.ThemeSlot)
+			if !utils.HasNestedThemeSlots(slot) {
+				utils.ShowTimedMessage(slot.TargetName + " has no folders to open", shortMessageDelay)
+				return te
+			}
+			state.AddNewMenuPosition()
+			nested := te
+			nested.Folders = append(append([]models.ThemeSlot{}, te.Folders...), slot)
+			return nested
+	}
+	state.RemoveMenuPositions(1)
+	if len(te.Folders) > 0 {
+		te.Folders = te.Folders[:len(te.Folders) - 1]
+		return te
+	}
+	if te.FromTools {
+		return ui.InitAestheticTools()
+	}
+	return ui.InitManageThemeOptions(te.Theme)
+}
+
+func handleThemeSlotOptionsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	tso := currentScreen.(ui.ThemeSlotOptions)
+	switch code {
+		case utils.ExitCodeSelect:
+			switch result.(string) {
+				case ui.AssignSlotName:
+					if !loadDecorationAggregations() {
+						return tso
+					}
+					state.AddNewMenuPosition()
+					return ui.InitThemeSlotDecorationBrowser(tso, ui.DefaultDecorationBrowserIndex)
+				case ui.PreviewSlotName:
+					utils.ConfirmActionCustomBack(splitPathToLines(tso.Slot.ImagePath), tso.Slot.ImagePath, "Back")
+					return tso
+				case ui.ClearSlotName:
+					if !confirmDeletion("Clear this image from the theme:\n" + splitPathToLines(tso.Slot.ImagePath), tso.Slot.ImagePath) {
+						return tso
+					}
+					if err := utils.ClearThemeSlot(tso.Slot); err != nil {
+						utils.ShowTimedMessage(fmt.Sprintf("Failed to delete:%s", splitPathToLines(tso.Slot.ImagePath)), shortMessageDelay)
+						return tso
+					}
+					utils.ShowTimedMessage(fmt.Sprintf("Deleted:\n%s", splitPathToLines(tso.Slot.ImagePath)), shortMessageDelay)
+			}
+	}
+	state.RemoveMenuPositions(1)
+	return tso.Editor
+}
+
+func handleComposeSourcePickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	csp := currentScreen.(ui.ComposeSourcePicker)
+	state.RemoveMenuPositions(1)
+	switch code {
+		case utils.ExitCodeSelect:
+			return ui.InitThemeComposer(utils.SetRecipeSource(csp.Recipe, csp.ComponentName, csp.ConsoleTag, result.(string)))
+	}
+	return ui.InitThemeComposer(csp.Recipe)
+}
+
+func handleComposeConsolePickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	ccp := currentScreen.(ui.ComposeConsolePicker)
+	state.RemoveMenuPositions(1)
+	switch code {
+		case utils.ExitCodeSelect:
+			state.AddNewMenuPosition()
+			return ui.InitComposeSourcePicker(ccp.Recipe, "", result.(string))
+	}
+	return ui.InitThemeComposer(ccp.Recipe)
+}
+
 func handleManageThemeOptionsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
 	mto := currentScreen.(ui.ManageThemeOptions)
 	switch code {
@@ -185,12 +692,59 @@ func handleManageThemeOptionsTransition(currentScreen models.Screen, result inte
 				case ui.RenameDisplayName:
 					updatedTheme := renameTheme(mto.Theme)
 					return ui.InitManageThemeOptions(updatedTheme)
+				case ui.RecolorDisplayName:
+					return ui.InitRecolorTheme(mto.Theme)
+				case ui.CoverageReportDisplayName:
+					state.AddNewMenuPosition()
+					return ui.InitCoverageReport(mto.Theme)
+				case ui.CompareDisplayName:
+					state.AddNewMenuPosition()
+					return ui.InitThemeCompareBase(mto.Theme)
+				case ui.EditThemeDisplayName:
+					state.AddNewMenuPosition()
+					return ui.InitThemeEditor(mto.Theme, nil)
+				case ui.EditRecipeDisplayName:
+					if recipe, found := utils.LoadThemeRecipe(mto.Theme); found {
+						state.AddNewMenuPosition()
+						return ui.InitThemeComposer(recipe)
+					}
+					return ui.InitManageThemeOptions(mto.Theme)
+				case ui.RegenerateDisplayName:
+					if recipe, found := utils.LoadThemeRecipe(mto.Theme); found {
+						composeTheme(recipe)
+					}
+					return ui.InitManageThemeOptions(mto.Theme)
 			}
 	}
 	state.RemoveMenuPositions(1)
 	return ui.InitManageThemes()
 }
 
+func handleRecolorThemeTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	rt := currentScreen.(ui.RecolorTheme)
+
+	switch code {
+		case utils.ExitCodeSelect:
+			selections := result.(models.RecolorSelections)
+			res, err := gaba.ProcessMessage("Creating recolored variant of " + rt.Theme.ThemeName, gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+				variant, count, err := utils.RecolorTheme(rt.Theme, selections)
+				if err != nil {
+					return count, err
+				}
+				return variant, nil
+			})
+			if err != nil {
+				utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+				return ui.InitManageThemeOptions(rt.Theme)
+			}
+			utils.ShowTimedMessage("Created theme: " + res.Result.(models.Theme).ThemeName, shortMessageDelay)
+			state.ClearDecorationAggregations()
+			state.RemoveMenuPositions(1)
+			return ui.InitManageThemes()
+	}
+	return ui.InitManageThemeOptions(rt.Theme)
+}
+
 func renameTheme(theme models.Theme) models.Theme {
 	themeParent := filepath.Dir(theme.ThemePath)
 	newThemeName := theme.ThemeName
@@ -284,20 +838,49 @@ func handleManageThemeComponentOptionsTransition(currentScreen models.Screen, re
 
 	switch code {
 		case utils.ExitCodeSelect:
-			selectedOptions := result.(models.ComponentOptionSelections)
-			res, _, modifyCount := utils.ApplyThemeComponentUpdates(mtco.Theme, mtco.Components, selectedOptions)
+			state.AddNewMenuPosition()
+			return ui.InitThemeTargetPicker(mtco.Theme, mtco.Components, mtco.ClearSelected, result.(models.ComponentOptionSelections))
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitManageThemeComponents(mtco.Theme)
+}
+
+func handleThemeTargetPickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	ttp := currentScreen.(ui.ThemeTargetPicker)
+
+	switch code {
+		case utils.ExitCodeSelect:
+			selectedOptions := ttp.Options
+			selectedOptions.SelectedTargets = result.(map[string]bool)
+			if len(selectedOptions.SelectedTargets) == 0 {
+				utils.ShowTimedMessage("Select at least one console", shortMessageDelay)
+				return ui.InitThemeTargetPicker(ttp.Theme, ttp.Components, ttp.ClearSelected, ttp.Options)
+			}
+			res, _, report := utils.ApplyThemeComponentUpdates(ttp.Theme, ttp.Components, selectedOptions)
+			modifyCount := report.ModifyCount
 			gaba.ResetBackground()
 			state.ClearDecorationAggregations()
+			showLockedDecorations(report.LockedDecorations)
 			if res != "" {
 				utils.ShowTimedMessage("Encountered error while " + res + "\nStopping and returning\n" + strconv.Itoa(modifyCount) + " updates made", longMessageDelay)
+				state.RemoveMenuPositions(1)
 				state.UpdateCurrentMenuPosition(0, 0)
-				return ui.InitManageThemeComponentOptions(mtco.Theme, mtco.Components, mtco.ClearSelected)
+				return ui.InitManageThemeComponentOptions(ttp.Theme, ttp.Components, ttp.ClearSelected)
+			} else if report.Cancelled {
+				utils.ShowTimedMessage(fmt.Sprintf("Cancelled\n%d updates made before stopping\n%d already up to date", modifyCount, report.UnchangedCount), longMessageDelay)
 			} else {
-				utils.ShowTimedMessage(strconv.Itoa(modifyCount) + " updates made", shortMessageDelay)
+				utils.ShowTimedMessage(fmt.Sprintf("%d updates made\n%d already up to date", modifyCount, report.UnchangedCount), shortMessageDelay)
+				if !utils.IsCurrentTheme(ttp.Theme) && modifyCount > 0 {
+					if wallpaperPath := utils.FindThemeAccentSource(ttp.Components); wallpaperPath != "" {
+						offerAccentColors(wallpaperPath)
+					}
+				}
 			}
+			state.RemoveMenuPositions(2)
+			return ui.InitManageThemeComponents(ttp.Theme)
 	}
 	state.RemoveMenuPositions(1)
-	return ui.InitManageThemeComponents(mtco.Theme)
+	return ui.InitManageThemeComponentOptions(ttp.Theme, ttp.Components, ttp.ClearSelected)
 }
 
 func handleDownloadThemesBrowserTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
@@ -402,7 +985,32 @@ func handleDecorationOptionsTransition(currentScreen models.Screen, result inter
 						}
 					}
 					return ui.InitDecorationOptions(do.RomDirectoryList, do.ListWallpaperSelected)
+				case ui.LockIconName, ui.UnlockIconName, ui.LockWallpaperName, ui.UnlockWallpaperName, ui.LockListWallpaperName, ui.UnlockListWallpaperName:
+					currentDirectory := do.RomDirectoryList[len(do.RomDirectoryList) - 1]
+					_, currentPath, parentPath := utils.GetCurrentDecorationDetails(do.RomDirectoryList)
+					destinationPath := ""
+					switch selectedAction {
+						case ui.LockIconName, ui.UnlockIconName:
+							destinationPath = utils.GetTrueIconPath(parentPath, currentDirectory.Path)
+						case ui.LockWallpaperName, ui.UnlockWallpaperName:
+							destinationPath = utils.GetTrueWallpaperPath(currentPath)
+						case ui.LockListWallpaperName, ui.UnlockListWallpaperName:
+							destinationPath = utils.GetTrueListWallpaperPath(currentPath)
+					}
+					lock := selectedAction == ui.LockIconName || selectedAction == ui.LockWallpaperName || selectedAction == ui.LockListWallpaperName
+					if err := utils.SetDecorationLock(destinationPath, lock); err != nil {
+						common.GetLoggerInstance().Error("Error saving decoration locks", zap.Error(err))
+						utils.ShowTimedMessage("Unable to save decoration locks", shortMessageDelay)
+					} else if lock {
+						utils.ShowTimedMessage(fmt.Sprintf("Locked:\n%s", splitPathToLines(destinationPath)), shortMessageDelay)
+					} else {
+						utils.ShowTimedMessage(fmt.Sprintf("Unlocked:\n%s", splitPathToLines(destinationPath)), shortMessageDelay)
+					}
+					return ui.InitDecorationOptions(do.RomDirectoryList, do.ListWallpaperSelected)
 				case ui.SelectIconName, ui.SelectWallpaperName, ui.SelectListWallpaperName:
+					if !loadDecorationAggregations() {
+						return ui.InitDecorationOptions(do.RomDirectoryList, do.ListWallpaperSelected)
+					}
 					state.AddNewMenuPosition()
 					return ui.InitDecorationBrowser(do.RomDirectoryList, do.ListWallpaperSelected, selectedAction, ui.DefaultDecorationBrowserIndex)
 				default:
@@ -420,6 +1028,9 @@ func handleDecorationOptionsTransition(currentScreen models.Screen, result inter
 
 func handleDecorationBrowserTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
 	db := currentScreen.(ui.DecorationBrowser)
+	if db.SlotOptions != nil {
+		return handleThemeSlotBrowserTransition(db, result, code)
+	}
 	if db.DecorationBrowserIndex == ui.DefaultDecorationBrowserIndex {
 		switch code {
 			case utils.ExitCodeSelect:
@@ -439,97 +1050,764 @@ func handleDecorationBrowserTransition(currentScreen models.Screen, result inter
 		case utils.ExitCodeSelect:
 			return copyFile(db.RomDirectoryList, db.ListWallpaperSelected, db.DecorationType, db.DecorationBrowserIndex, result.(models.Decoration))
 		case utils.ExitCodeAction:
-			decoration := result.(models.Decoration)
-			if confirmDeletion("Delete this decoration from:\n" + splitPathToLines(decoration.DecorationPath), decoration.DecorationPath) {
-				res := common.DeleteFile(decoration.DecorationPath)
-				if res {
-					// Successful file deletion. Clear from aggregations
-					// TODO: Possibly run additional logic checks to change selected item state?
-					consoleAggregation, decorationAggregation := state.GetDecorationAggregation()
-					shouldBreak := false
-					for index, agg := range consoleAggregation {
-						if decoration.ConsoleName == agg.ConsoleName {
-							for subIndex, dec := range agg.DecorationList {
-								if decoration.DecorationName == dec.DecorationName {
-									agg.DecorationList = append(agg.DecorationList[:subIndex], agg.DecorationList[subIndex+1:]...)
-									consoleAggregation[index] = agg
-									if len(agg.DecorationList) == 0 {
-										consoleAggregation = append(consoleAggregation[:index], consoleAggregation[index+1:]...)
-									}
-									shouldBreak = true
-									break
-								}
-							}
-							if shouldBreak {
-								break
-							}
-						}
-					}
-					shouldBreak = false
-					for index, agg := range decorationAggregation {
-						if decoration.DirectoryName == agg.DirectoryName {
-							for subIndex, dec := range agg.DecorationList {
-								if decoration.DecorationName == dec.DecorationName {
-									agg.DecorationList = append(agg.DecorationList[:subIndex], agg.DecorationList[subIndex+1:]...)
-									decorationAggregation[index] = agg
-									if len(agg.DecorationList) == 0 {
-										decorationAggregation = append(decorationAggregation[:index], decorationAggregation[index+1:]...)
-									}
-									shouldBreak = true
-									break
-								}
-							}
-							if shouldBreak {
-								break
-							}
-						}
-					}
-					utils.ShowTimedMessage(fmt.Sprintf("Deleted:\n%s", splitPathToLines(decoration.DecorationPath)), shortMessageDelay)
-				} else {
-					utils.ShowTimedMessage(fmt.Sprintf("Failed to delete:%s", splitPathToLines(decoration.DecorationPath)), shortMessageDelay)
-				}
-			}
-			return ui.InitDecorationBrowser(db.RomDirectoryList, db.ListWallpaperSelected, db.DecorationType, db.DecorationBrowserIndex)
+			state.AddNewMenuPosition()
+			return ui.InitDecorationActions(db, result.(models.Decoration))
 		default:
 			state.RemoveMenuPositions(1)
 			return ui.InitDecorationBrowser(db.RomDirectoryList, db.ListWallpaperSelected, db.DecorationType, ui.DefaultDecorationBrowserIndex)
 	}
 }
 
-func copyFile(romDirectoryList []shared.RomDirectory, listWallpaperSelected bool, decorationType string, decorationBrowserIndex int, decoration models.Decoration) models.Screen {
-	currentDirectory := romDirectoryList[len(romDirectoryList) - 1]
-	_, currentPath, parentPath := utils.GetCurrentDecorationDetails(romDirectoryList)
-	sourcePath := decoration.DecorationPath
-	destinationPath := ""
-	utils.CheckIconPath(parentPath, currentDirectory.Path)
-	utils.CheckWallpaperPath(currentPath)
-	utils.CheckListWallpaperPath(currentPath)
-	switch decorationType {
-		case ui.SelectIconName:
-			destinationPath = utils.GetTrueIconPath(parentPath, currentDirectory.Path)
-		case ui.SelectWallpaperName:
-			destinationPath = utils.GetTrueWallpaperPath(currentPath)
-		case ui.SelectListWallpaperName:
-			destinationPath = utils.GetTrueListWallpaperPath(currentPath)
+func handleDecorationActionsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	da := currentScreen.(ui.DecorationActions)
+	switch code {
+		case utils.ExitCodeSelect:
+			switch result.(string) {
+				case ui.AddToThemeName:
+					state.AddNewMenuPosition()
+					return ui.InitAddToThemeSelection(da)
+				case ui.ApplyToFoldersName:
+					state.AddNewMenuPosition()
+					return ui.InitBulkApplyMode(da)
+				case ui.DeleteDecorationName:
+					deleteDecoration(da.Decoration)
+			}
 	}
-	// message := "Copy image from:\n" + splitPathToLines(sourcePath) + "\nto\n" + splitPathToLines(destinationPath)
-	message := "Copy image to:\n" + splitPathToLines(destinationPath)
+	state.RemoveMenuPositions(1)
+	return da.Browser
+}
+
+func handleAddToThemeSelectionTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	ats := currentScreen.(ui.AddToThemeSelection)
+	switch code {
+		case utils.ExitCodeSelect:
+			decorations := result.([]models.Decoration)
+			if len(decorations) == 0 {
+				utils.ShowTimedMessage("No images chosen", shortMessageDelay)
+				return ats
+			}
+			state.AddNewMenuPosition()
+			return ui.InitAddToThemePicker(ats, decorations)
+	}
+	state.RemoveMenuPositions(1)
+	return ats.Actions
+}
+
+func handleAddToThemePickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	atp := currentScreen.(ui.AddToThemePicker)
+	switch code {
+		case utils.ExitCodeSelect:
+			state.AddNewMenuPosition()
+			return ui.InitThemeSlotPicker(atp, result.(models.Theme), nil)
+	}
+	state.RemoveMenuPositions(1)
+	return atp.Selection
+}
+
+func handleThemeSlotPickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	tsp := currentScreen.(ui.ThemeSlotPicker)
+	switch code {
+		case utils.ExitCodeSelect:
+			assignments := append(append([]models.ThemeSlotAssignment{}, tsp.Assignments...), models.ThemeSlotAssignment{
+				Slot:		result.(models.ThemeSlot),
+				SourcePath:	tsp.CurrentDecoration().DecorationPath,
+			})
+			state.UpdateCurrentMenuPosition(0, 0)
+			if len(assignments) < len(tsp.Picker.Decorations) {
+				return ui.InitThemeSlotPicker(tsp.Picker, tsp.Theme, assignments)
+			}
+			assignments = utils.DropSelfAssignments(assignments)
+			if len(assignments) == 0 {
+				utils.ShowTimedMessage("These images are already in " + tsp.Theme.ThemeName, shortMessageDelay)
+				state.RemoveMenuPositions(4)
+				return tsp.Picker.Selection.Actions.Browser
+			}
+			if !confirmThemeSlotAssignments(tsp.Theme, assignments) {
+				return tsp
+			}
+			addCount, err := utils.AddDecorationsToTheme(assignments)
+			if addCount > 0 {
+				// Themes are decoration sources, so the added images have to show up in the browser
+				state.ClearDecorationAggregations()
+			}
+			if err != nil {
+				utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+				return tsp
+			}
+			utils.ShowTimedMessage(fmt.Sprintf("Added %d images to %s", addCount, tsp.Theme.ThemeName), shortMessageDelay)
+			state.RemoveMenuPositions(4)
+			return tsp.Picker.Selection.Actions.Browser
+	}
+	// Backing out steps to the previous decoration before leaving the batch
+	if len(tsp.Assignments) > 0 {
+		state.UpdateCurrentMenuPosition(0, 0)
+		return ui.InitThemeSlotPicker(tsp.Picker, tsp.Theme, tsp.Assignments[:len(tsp.Assignments) - 1])
+	}
+	state.RemoveMenuPositions(1)
+	return tsp.Picker
+}
+
+// confirmThemeSlotAssignments asks before adding a batch, listing the theme images that would be overwritten
+func confirmThemeSlotAssignments(theme models.Theme, assignments []models.ThemeSlotAssignment) bool {
+	const shownLimit = 3
+	message := fmt.Sprintf("Add %d images to %s?", len(assignments), theme.ThemeName)
+	overwritten := utils.OverwrittenThemeSlots(theme, assignments)
+	if len(overwritten) > 0 {
+		message = message + fmt.Sprintf("\nOverwrites %d:", len(overwritten))
+		for _, overwrittenPath := range overwritten[:min(shownLimit, len(overwritten))] {
+			message = message + "\n" + overwrittenPath
+		}
+		if len(overwritten) > shownLimit {
+			message = message + fmt.Sprintf("\n...and %d more", len(overwritten) - shownLimit)
+		}
+	}
+	imagePath := ""
+	if len(assignments) == 1 {
+		imagePath = assignments[0].SourcePath
+	}
+	return utils.ConfirmAction(message, imagePath)
+}
+
+// deleteDecoration deletes a decoration after confirmation and drops it from the cached aggregations
+func deleteDecoration(decoration models.Decoration) {
+	if confirmDeletion("Delete this decoration from:\n" + splitPathToLines(decoration.DecorationPath), decoration.DecorationPath) {
+		res := common.DeleteFile(decoration.DecorationPath)
+		if res {
+			// Successful file deletion. Clear from aggregations
+			// TODO: Possibly run additional logic checks to change selected item state?
+			consoleAggregation, decorationAggregation := state.GetDecorationAggregation()
+			shouldBreak := false
+			for index, agg := range consoleAggregation {
+				if decoration.ConsoleName == agg.ConsoleName {
+					for subIndex, dec := range agg.DecorationList {
+						if decoration.DecorationName == dec.DecorationName {
+							agg.DecorationList = append(agg.DecorationList[:subIndex], agg.DecorationList[subIndex+1:]...)
+							consoleAggregation[index] = agg
+							if len(agg.DecorationList) == 0 {
+								consoleAggregation = append(consoleAggregation[:index], consoleAggregation[index+1:]...)
+							}
+							shouldBreak = true
+							break
+						}
+					}
+					if shouldBreak {
+						break
+					}
+				}
+			}
+			shouldBreak = false
+			for index, agg := range decorationAggregation {
+				if decoration.DirectoryName == agg.DirectoryName {
+					for subIndex, dec := range agg.DecorationList {
+						if decoration.DecorationName == dec.DecorationName {
+							agg.DecorationList = append(agg.DecorationList[:subIndex], agg.DecorationList[subIndex+1:]...)
+							decorationAggregation[index] = agg
+							if len(agg.DecorationList) == 0 {
+								decorationAggregation = append(decorationAggregation[:index], decorationAggregation[index+1:]...)
+							}
+							shouldBreak = true
+							break
+						}
+					}
+					if shouldBreak {
+						break
+					}
+				}
+			}
+			// Storing the trimmed lists also drops the groups built from the old ones
+			state.SetDecorationAggregations(consoleAggregation, decorationAggregation)
+			utils.ShowTimedMessage(fmt.Sprintf("Deleted:\n%s", splitPathToLines(decoration.DecorationPath)), shortMessageDelay)
+		} else {
+			utils.ShowTimedMessage(fmt.Sprintf("Failed to delete:%s", splitPathToLines(decoration.DecorationPath)), shortMessageDelay)
+		}
+	}
+}
+
+func handleThemeSlotBrowserTransition(db ui.DecorationBrowser, result interface{}, code int) models.Screen {
+	tso := *db.SlotOptions
+	if db.DecorationBrowserIndex == ui.DefaultDecorationBrowserIndex {
+		switch code {
+			case utils.ExitCodeSelect:
+				state.AddNewMenuPosition()
+				return ui.InitThemeSlotDecorationBrowser(tso, result.(int))
+			case utils.ExitCodeAction:
+				state.UpdateCurrentMenuPosition(0, 0)
+				state.CycleAggregationMode()
+				utils.SaveConfig(state.GetAppState().Config)
+				return db
+		}
+		state.RemoveMenuPositions(1)
+		return tso
+	}
+	switch code {
+		case utils.ExitCodeSelect:
+			decoration := result.(models.Decoration)
+			if !utils.ConfirmAction("Copy image to theme:\n" + splitPathToLines(tso.Slot.SlotPath), decoration.DecorationPath) {
+				return db
+			}
+			if err := utils.SetThemeSlotImage(tso.Slot, decoration.DecorationPath); err != nil {
+				utils.ShowTimedMessage("Unable to copy image!", longMessageDelay)
+				return db
+			}
+			// Themes are decoration sources, so the new slot image has to show up in the browser
+			state.ClearDecorationAggregations()
+			utils.ShowTimedMessage("Image copied successfully!", shortMessageDelay)
+			state.RemoveMenuPositions(3)
+			return tso.Editor
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitThemeSlotDecorationBrowser(tso, ui.DefaultDecorationBrowserIndex)
+}
+
+func getDecorationDestinationPath(romDirectoryList []shared.RomDirectory, decorationType string) string {
+	currentDirectory := romDirectoryList[len(romDirectoryList) - 1]
+	_, currentPath, parentPath := utils.GetCurrentDecorationDetails(romDirectoryList)
+	switch decorationType {
+		case ui.SelectIconName:
+			return utils.GetTrueIconPath(parentPath, currentDirectory.Path)
+		case ui.SelectWallpaperName:
+			return utils.GetTrueWallpaperPath(currentPath)
+		case ui.SelectListWallpaperName:
+			return utils.GetTrueListWallpaperPath(currentPath)
+	}
+	return ""
+}
+
+func copyFile(romDirectoryList []shared.RomDirectory, listWallpaperSelected bool, decorationType string, decorationBrowserIndex int, decoration models.Decoration) models.Screen {
+	sourcePath := decoration.DecorationPath
+	destinationPath := getDecorationDestinationPath(romDirectoryList, decorationType)
+	if utils.IsCroppableDecoration(sourcePath) && utils.ConfirmActionCustomBack("Crop this screenshot before applying?", sourcePath, "Use Full Image") {
+		return ui.InitCropDecoration(romDirectoryList, listWallpaperSelected, decorationType, decorationBrowserIndex, decoration, ui.DefaultCropSelections)
+	}
+	// message := "Copy image from:\n" + splitPathToLines(sourcePath) + "\nto\n" + splitPathToLines(destinationPath)
+	message := "Copy image to:\n" + splitPathToLines(destinationPath)
 	if utils.ConfirmAction(message, sourcePath) {
-		err := utils.CopyFile(sourcePath, destinationPath)
+		err := copyDecoration(sourcePath, destinationPath)
 		if err != nil {
 			utils.ShowTimedMessage("Unable to copy image!", longMessageDelay)
 			return ui.InitDecorationBrowser(romDirectoryList, listWallpaperSelected, decorationType, decorationBrowserIndex)
 		}
-		if destinationPath == "/mnt/SDCARD/bg.png" {
-			gaba.ResetBackground()
-		}
 		utils.ShowTimedMessage("Image copied successfully!", shortMessageDelay)
+		if decorationType == ui.SelectWallpaperName || decorationType == ui.SelectListWallpaperName {
+			offerAccentColors(destinationPath)
+		}
 		state.RemoveMenuPositions(2)
 		return ui.InitDecorationOptions(romDirectoryList, listWallpaperSelected)
 	}
 	return ui.InitDecorationBrowser(romDirectoryList, listWallpaperSelected, decorationType, decorationBrowserIndex)
 }
 
+// copyDecoration copies an image into place on the device, refreshing the app background when it was replaced
+func copyDecoration(sourcePath string, destinationPath string) error {
+	if err := utils.CopyFile(sourcePath, destinationPath); err != nil {
+		return err
+	}
+	if destinationPath == "/mnt/SDCARD/bg.png" {
+		gaba.ResetBackground()
+	}
+	return nil
+}
+
+func handleBulkApplyModeTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	bam := currentScreen.(ui.BulkApplyMode)
+	switch code {
+		case utils.ExitCodeSelect:
+			var folders []string
+			var err error
+			selectAll := true
+			switch result.(string) {
+				case ui.BulkChooseFoldersName:
+					folders = utils.ListSubfolders(bam.BrowsedFolder())
+					selectAll = false
+				case ui.BulkSubfoldersName:
+					folders = utils.ListSubfolders(bam.CurrentFolder())
+				case ui.BulkConsoleTagName:
+					folders, err = utils.ListConsoleFoldersWithTag(bam.ConsoleTag())
+				case ui.BulkMissingName:
+					folders, err = utils.ListConsoleFoldersMissing(bam.Actions.Browser.ComponentType())
+			}
+			if err != nil {
+				utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+				return bam
+			}
+			if len(folders) == 0 {
+				utils.ShowTimedMessage("No folders found", shortMessageDelay)
+				return bam
+			}
+			state.AddNewMenuPosition()
+			return ui.InitBulkTargetPicker(bam, folders, selectAll)
+	}
+	state.RemoveMenuPositions(1)
+	return bam.Actions
+}
+
+func handleBulkTargetPickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	btp := currentScreen.(ui.BulkTargetPicker)
+	switch code {
+		case utils.ExitCodeSelect:
+			folders := result.([]string)
+			if len(folders) == 0 {
+				utils.ShowTimedMessage("No folders chosen", shortMessageDelay)
+				return btp
+			}
+			componentType := btp.Mode.Actions.Browser.ComponentType()
+			sourcePath := btp.Mode.Actions.Decoration.DecorationPath
+			if !utils.ConfirmAction(fmt.Sprintf("Apply this %s to %d folders?", componentType, len(folders)), sourcePath) {
+				return btp
+			}
+			applyCount, lockedDecorations, err := utils.ApplyDecorationToFolders(sourcePath, folders, componentType, copyDecoration)
+			if err != nil {
+				utils.ShowTimedMessage(fmt.Sprintf("Applied to %d folders\nError encountered: %s", applyCount, err.Error()), longMessageDelay)
+			} else {
+				utils.ShowTimedMessage(fmt.Sprintf("Applied to %d folders", applyCount), shortMessageDelay)
+			}
+			showLockedDecorations(lockedDecorations)
+			state.RemoveMenuPositions(3)
+			return btp.Mode.Actions.Browser
+	}
+	state.RemoveMenuPositions(1)
+	return btp.Mode
+}
+
+func handleAutoDecorateTransition(result interface{}, code int) models.Screen {
+	switch code {
+		case utils.ExitCodeSelect:
+			selections := result.(models.AutoDecorateSelections)
+			if !loadDecorationAggregations() {
+				return ui.InitAutoDecorate()
+			}
+			consoleAggregation, _ := state.GetDecorationAggregation()
+			var plan []models.DecorationPlanEntry
+			var lockedDecorations []string
+			_, err := gaba.ProcessMessage("Choosing wallpapers", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+				var err error
+				plan, lockedDecorations, err = utils.GetAutoDecoratePlan(consoleAggregation, selections)
+				return plan, err
+			})
+			if err != nil {
+				utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+				return ui.InitAutoDecorate()
+			}
+			showLockedDecorations(lockedDecorations)
+			if len(plan) == 0 {
+				utils.ShowTimedMessage("No folders to decorate", shortMessageDelay)
+				return ui.InitAutoDecorate()
+			}
+			state.AddNewMenuPosition()
+			return ui.InitAutoDecoratePlan(selections, plan)
+	}
+	return ui.InitAestheticTools()
+}
+
+func handleAutoDecoratePlanTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	adp := currentScreen.(ui.AutoDecoratePlan)
+	switch code {
+		case utils.ExitCodeSelect:
+			plan := result.([]models.DecorationPlanEntry)
+			if len(plan) == 0 {
+				utils.ShowTimedMessage("No folders chosen", shortMessageDelay)
+				return adp
+			}
+			if !utils.ConfirmAction(fmt.Sprintf("Set wallpapers for %d folders?", len(plan)), "") {
+				return adp
+			}
+			applyCount, err := utils.ApplyDecorationPlan(plan, copyDecoration)
+			if err != nil {
+				utils.ShowTimedMessage(fmt.Sprintf("Decorated %d folders\nError encountered: %s", applyCount, err.Error()), longMessageDelay)
+			} else {
+				utils.ShowTimedMessage(fmt.Sprintf("Decorated %d folders", applyCount), shortMessageDelay)
+			}
+			state.RemoveMenuPositions(1)
+			return ui.InitAestheticTools()
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitAutoDecorate()
+}
+
+func handleShuffleDecorationsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	sd := currentScreen.(ui.ShuffleDecorations)
+	switch code {
+		case utils.ExitCodeSelect:
+			shuffleOptions := result.(ui.ShuffleOptions)
+			if shuffleOptions.ChooseConsoles {
+				state.AddNewMenuPosition()
+				return ui.InitShuffleConsolePicker(shuffleOptions)
+			}
+			if shuffleOptions.ForRotation {
+				addShuffleToRotation(shuffleOptions)
+				return ui.InitThemeRotation()
+			}
+			shuffleDecorations(shuffleOptions)
+	}
+	if sd.ForRotation {
+		return ui.InitThemeRotation()
+	}
+	return ui.InitAestheticTools()
+}
+
+func handleShuffleConsolePickerTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	scp := currentScreen.(ui.ShuffleConsolePicker)
+	switch code {
+		case utils.ExitCodeSelect:
+			consoleTags := result.([]string)
+			if len(consoleTags) == 0 {
+				utils.ShowTimedMessage("No consoles chosen", shortMessageDelay)
+				return scp
+			}
+			shuffleOptions := scp.Options
+			shuffleOptions.Selections.ConsoleTags = consoleTags
+			state.RemoveMenuPositions(1)
+			if shuffleOptions.ForRotation {
+				addShuffleToRotation(shuffleOptions)
+				return ui.InitThemeRotation()
+			}
+			shuffleDecorations(shuffleOptions)
+			return ui.InitAestheticTools()
+	}
+	state.RemoveMenuPositions(1)
+	sd := ui.InitShuffleDecorations()
+	sd.ForRotation = scp.Options.ForRotation
+	return sd
+}
+
+// shuffleDecorations applies a shuffle to the device, reports its seed, and offers to keep it as a theme
+func shuffleDecorations(shuffleOptions ui.ShuffleOptions) {
+	selections := shuffleOptions.Selections
+	selections.Seed = utils.NewShuffleSeed()
+	if shuffleOptions.EnterSeed {
+		seed, entered := promptShuffleSeed()
+		if !entered {
+			return
+		}
+		selections.Seed = seed
+	}
+	if !loadDecorationAggregations() {
+		return
+	}
+	consoleAggregation, _ := state.GetDecorationAggregation()
+
+	var plan []models.DecorationPlanEntry
+	var lockedDecorations []string
+	res, err := gaba.ProcessMessage("Shuffling decorations", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		var err error
+		plan, lockedDecorations, err = utils.PlanShuffle(consoleAggregation, selections)
+		if err != nil {
+			return 0, err
+		}
+		return utils.ApplyDecorationPlan(plan, copyDecoration)
+	})
+	if err != nil {
+		utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+		return
+	}
+	applyCount := res.Result.(int)
+	utils.ShowTimedMessage(fmt.Sprintf("Shuffled %d decorations\nSeed: %d", applyCount, selections.Seed), standardMessageDelay)
+	showLockedDecorations(lockedDecorations)
+	if applyCount == 0 || !utils.ConfirmAction(fmt.Sprintf("Save shuffle %d as a theme?", selections.Seed), "") {
+		return
+	}
+
+	res, err = gaba.ProcessMessage("Saving shuffle", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		theme, _, err := utils.SaveShuffleAsTheme(plan, selections.Seed)
+		return theme, err
+	})
+	if err != nil {
+		utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+		return
+	}
+	utils.ShowTimedMessage("Saved as " + res.Result.(models.Theme).ThemeName, shortMessageDelay)
+}
+
+func handleThemeRotationTransition(result interface{}, code int) models.Screen {
+	switch code {
+		case utils.ExitCodeSelect:
+			rotation := utils.LoadThemeRotation()
+			switch entryIndex := result.(int); entryIndex {
+				case ui.RotationPolicyIndex:
+					rotation.Policy = utils.NextRotationPolicy(rotation.Policy)
+					saveThemeRotation(rotation)
+				case ui.AddRotationThemeIndex:
+					state.AddNewMenuPosition()
+					return ui.InitRotationThemePicker()
+				case ui.AddRotationShuffleIndex:
+					sd := ui.InitShuffleDecorations()
+					sd.ForRotation = true
+					return sd
+				default:
+					return ui.InitRotationEntryOptions(entryIndex, rotation.Entries[entryIndex])
+			}
+			return ui.InitThemeRotation()
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitAestheticTools()
+}
+
+func handleRotationThemePickerTransition(result interface{}, code int) models.Screen {
+	switch code {
+		case utils.ExitCodeSelect:
+			addRotationEntry(models.RotationEntry{ThemeName: result.(string)})
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitThemeRotation()
+}
+
+func handleRotationEntryOptionsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	reo := currentScreen.(ui.RotationEntryOptions)
+	switch code {
+		case utils.ExitCodeSelect:
+			selections := result.(models.RotationEntrySelections)
+			rotation := utils.LoadThemeRotation()
+			if reo.EntryIndex >= len(rotation.Entries) {
+				return ui.InitThemeRotation()
+			}
+			if selections.Remove {
+				if utils.ConfirmAction("Remove " + utils.DescribeRotationEntry(reo.Entry) + " from the rotation?", "") {
+					// Indexes past the removed entry shift down, so the rotation forgets what it last applied
+					rotation.Entries = append(rotation.Entries[:reo.EntryIndex], rotation.Entries[reo.EntryIndex+1:]...)
+					rotation.AppliedIndex = -1
+					if reo.EntryIndex < rotation.NextIndex {
+						rotation.NextIndex--
+					}
+					state.UpdateCurrentMenuPosition(0, 0)
+					saveThemeRotation(rotation)
+				}
+				return ui.InitThemeRotation()
+			}
+			rotation.Entries[reo.EntryIndex].StartHour = selections.StartHour
+			saveThemeRotation(rotation)
+	}
+	return ui.InitThemeRotation()
+}
+
+// addShuffleToRotation adds a shuffle to the rotation, asking for its seed first when one is to be entered
+func addShuffleToRotation(shuffleOptions ui.ShuffleOptions) {
+	selections := shuffleOptions.Selections
+	if shuffleOptions.EnterSeed {
+		seed, entered := promptShuffleSeed()
+		if !entered {
+			return
+		}
+		selections.Seed = seed
+	}
+	addRotationEntry(models.RotationEntry{Shuffle: &selections})
+}
+
+func addRotationEntry(entry models.RotationEntry) {
+	rotation := utils.LoadThemeRotation()
+	rotation.Entries = append(rotation.Entries, entry)
+	if saveThemeRotation(rotation) {
+		utils.ShowTimedMessage("Added " + utils.DescribeRotationEntry(entry) + " to the rotation", shortMessageDelay)
+	}
+}
+
+// saveThemeRotation stores the edited rotation, reporting whether it was saved
+func saveThemeRotation(rotation models.ThemeRotation) bool {
+	if err := utils.SaveThemeRotation(rotation); err != nil {
+		utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+		return false
+	}
+	return true
+}
+
+func handleProfilesTransition(result interface{}, code int) models.Screen {
+	switch code {
+		case utils.ExitCodeSelect:
+			profileName := result.(string)
+			if profileName == "" {
+				createProfile()
+				return ui.InitProfiles()
+			}
+			state.AddNewMenuPosition()
+			return ui.InitProfileOptions(profileName)
+	}
+	state.ReturnToMain()
+	return ui.InitMainMenu()
+}
+
+func handleProfileOptionsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	po := currentScreen.(ui.ProfileOptions)
+	switch code {
+		case utils.ExitCodeSelect:
+			switch result.(string) {
+				case ui.SwitchProfileName:
+					message := "Switch to profile " + po.ProfileName + "?"
+					if activeProfile := utils.GetActiveProfile(); activeProfile != "" {
+						message = message + "\nThe device is saved into " + activeProfile + " first"
+					} else if utils.ConfirmActionCustomBack("The device has no profile.\nSave it as a new profile first?", "", "Don't Save") {
+						// Saving makes the new profile active, so the switch keeps it up to date as well
+						if !createProfile() {
+							return po
+						}
+						message = message + "\nThe device is saved into " + utils.GetActiveProfile() + " first"
+					} else {
+						message = message + "\nThe device's decorations will be lost"
+					}
+					if !utils.ConfirmAction(message, "") {
+						return po
+					}
+					report, err := utils.SwitchProfile(po.ProfileName)
+					gaba.ResetBackground()
+					state.ClearDecorationAggregations()
+					showProfileUpdateReport(report, err)
+				case ui.SaveProfileName:
+					report, err := utils.SaveProfile(po.ProfileName)
+					showProfileUpdateReport(report, err)
+				case ui.DeleteProfileName:
+					if !confirmDeletion("Delete profile " + po.ProfileName + "?\nThe device is left as it is.", "") {
+						return po
+					}
+					if err := utils.DeleteProfile(po.ProfileName); err != nil {
+						utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+					} else {
+						state.RemoveMenuPositions(1)
+						state.UpdateCurrentMenuPosition(0, 0)
+						return ui.InitProfiles()
+					}
+			}
+	}
+	state.RemoveMenuPositions(1)
+	return ui.InitProfiles()
+}
+
+// createProfile names a new profile and saves the device into it, reporting whether the save finished
+func createProfile() bool {
+	profileName := ""
+	for {
+		res, err := gaba.Keyboard(profileName)
+		if err != nil {
+			utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+			return false
+		}
+		if !res.IsSome() || res.Unwrap() == "" {
+			return false
+		}
+		profileName = strings.TrimSpace(res.Unwrap())
+		if err := utils.ValidateProfileName(profileName); err != nil {
+			utils.ShowTimedMessage(err.Error(), standardMessageDelay)
+			continue
+		}
+		break
+	}
+	report, err := utils.SaveProfile(profileName)
+	showProfileUpdateReport(report, err)
+	return err == nil && !report.Cancelled
+}
+
+func showProfileUpdateReport(report models.ThemeUpdateReport, err error) {
+	showLockedDecorations(report.LockedDecorations)
+	if err != nil {
+		utils.ShowTimedMessage(fmt.Sprintf("Error encountered: %s\n%d updates made", err.Error(), report.ModifyCount), longMessageDelay)
+	} else if report.Cancelled {
+		utils.ShowTimedMessage(fmt.Sprintf("Cancelled\n%d updates made before stopping\n%d already up to date", report.ModifyCount, report.UnchangedCount), longMessageDelay)
+	} else {
+		utils.ShowTimedMessage(fmt.Sprintf("%d updates made\n%d already up to date", report.ModifyCount, report.UnchangedCount), shortMessageDelay)
+	}
+}
+
+// promptShuffleSeed asks for the seed of an earlier shuffle, repeating until a number is entered or the prompt is left
+func promptShuffleSeed() (int64, bool) {
+	seedText := ""
+	for {
+		res, err := gaba.Keyboard(seedText)
+		if err != nil {
+			utils.ShowTimedMessage("Error encountered: " + err.Error(), longMessageDelay)
+			return 0, false
+		}
+		if !res.IsSome() || res.Unwrap() == "" {
+			return 0, false
+		}
+		seedText = res.Unwrap()
+		seed, err := strconv.ParseInt(strings.TrimSpace(seedText), 10, 64)
+		if err != nil {
+			utils.ShowTimedMessage(seedText + "\nis not a seed.\nSeeds are whole numbers like 123456", standardMessageDelay)
+			continue
+		}
+		return seed, true
+	}
+}
+
+func handleCropDecorationTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	cd := currentScreen.(ui.CropDecoration)
+
+	switch code {
+		case utils.ExitCodeSelect:
+			selections := result.(models.CropSelections)
+			lockSquare := cd.DecorationType == ui.SelectIconName
+			destinationPath := getDecorationDestinationPath(cd.RomDirectoryList, cd.DecorationType)
+			previewPath, err := utils.GenerateCropPreview(cd.Decoration.DecorationPath, selections, lockSquare)
+			if err != nil {
+				utils.ShowTimedMessage("Unable to preview crop!", longMessageDelay)
+				return ui.InitCropDecoration(cd.RomDirectoryList, cd.ListWallpaperSelected, cd.DecorationType, cd.DecorationBrowserIndex, cd.Decoration, selections)
+			}
+			if !utils.ConfirmActionCustomBack("Save this crop to:\n" + splitPathToLines(destinationPath), previewPath, "Adjust") {
+				return ui.InitCropDecoration(cd.RomDirectoryList, cd.ListWallpaperSelected, cd.DecorationType, cd.DecorationBrowserIndex, cd.Decoration, selections)
+			}
+			if err := utils.SaveCroppedDecoration(cd.Decoration.DecorationPath, destinationPath, selections, lockSquare); err != nil {
+				utils.ShowTimedMessage("Unable to save cropped image!", longMessageDelay)
+				return ui.InitCropDecoration(cd.RomDirectoryList, cd.ListWallpaperSelected, cd.DecorationType, cd.DecorationBrowserIndex, cd.Decoration, selections)
+			}
+			if destinationPath == "/mnt/SDCARD/bg.png" {
+				gaba.ResetBackground()
+			}
+			utils.ShowTimedMessage("Cropped image saved successfully!", shortMessageDelay)
+			if cd.DecorationType == ui.SelectWallpaperName || cd.DecorationType == ui.SelectListWallpaperName {
+				offerAccentColors(destinationPath)
+			}
+			state.RemoveMenuPositions(2)
+			return ui.InitDecorationOptions(cd.RomDirectoryList, cd.ListWallpaperSelected)
+	}
+	return ui.InitDecorationBrowser(cd.RomDirectoryList, cd.ListWallpaperSelected, cd.DecorationType, cd.DecorationBrowserIndex)
+}
+
+func offerAccentColors(wallpaperPath string) {
+	res, err := gaba.ProcessMessage("Finding accent colors", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		suggestion, err := utils.SuggestAccentColors(wallpaperPath)
+		if err != nil {
+			return nil, err
+		}
+		swatchPath, err := utils.GeneratePaletteSwatch(wallpaperPath, suggestion)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{suggestion, swatchPath}, nil
+	})
+	if err != nil {
+		// Suggestions are a nicety. Failing to find one should never interrupt the wallpaper flow
+		common.GetLoggerInstance().Error("Unable to suggest accent colors", zap.Error(err))
+		return
+	}
+	suggestionResult := res.Result.([]interface{})
+	suggestion := suggestionResult[0].(utils.AccentSuggestion)
+	message := fmt.Sprintf("Use matching accent colors?\nMain: %s\nPrimary: %s\nSecondary: %s",
+		utils.FormatNextUIColor(suggestion.MainColor),
+		utils.FormatNextUIColor(suggestion.PrimaryAccent),
+		utils.FormatNextUIColor(suggestion.SecondaryAccent),
+	)
+	if utils.ConfirmActionCustomBack(message, suggestionResult[1].(string), "Skip") {
+		if err := utils.ApplyAccentColors(suggestion); err != nil {
+			utils.ShowTimedMessage("Unable to save accent colors!", longMessageDelay)
+			return
+		}
+		utils.ShowTimedMessage("Accent colors saved!", shortMessageDelay)
+	}
+}
+
+// showLockedDecorations names the first few locked decorations an update or plan left alone
+func showLockedDecorations(lockedDecorations []string) {
+	const shownLimit = 3
+	if len(lockedDecorations) == 0 {
+		return
+	}
+	message := fmt.Sprintf("Skipped %d locked decorations:", len(lockedDecorations))
+	for _, lockedPath := range lockedDecorations[:min(shownLimit, len(lockedDecorations))] {
+		message = message + "\n" + strings.TrimPrefix(lockedPath, common.SDCardRoot + "/")
+	}
+	if len(lockedDecorations) > shownLimit {
+		message = message + fmt.Sprintf("\n...and %d more", len(lockedDecorations) - shownLimit)
+	}
+	utils.ShowTimedMessage(message, longMessageDelay)
+}
+
 func splitPathToLines(filePath string) string {
 	splitList := strings.Split(filePath, "/")
 	widthList := []string{""}
@@ -568,3 +1846,67 @@ func confirmDeletion(message, imagePath string) bool {
 
 	return err == nil && result.IsSome()
 }
+
+// loadDecorationAggregations runs the decoration scan in the background so the directory count stays on screen.
+// Pressing B stops the scan after the directory in progress
+func loadDecorationAggregations() bool {
+	if state.HasDecorationAggregations() {
+		return true
+	}
+	sources := utils.GetDecorationSources(state.GetAppState().Config)
+	progress := &utils.DecorationScanProgress{}
+	progressBar := &atomic.Float64{}
+	scanFinished := make(chan struct{})
+	var consoleAggregation []models.ConsoleAggregation
+	var directoryAggregation []models.DirectoryAggregation
+	go func() {
+		consoleAggregation, directoryAggregation = utils.GenerateDecorationAggregations(sources, progress)
+		close(scanFinished)
+	}()
+
+	stopWatching := utils.WatchForBackButton(progress.Cancel)
+	defer stopWatching()
+	for {
+		message := fmt.Sprintf("Scanning decorations\n%d directories scanned\nPress B to cancel", progress.DirectoriesScanned.Load())
+		if progress.IsCancelled() {
+			message = fmt.Sprintf("Cancelling scan\n%d directories scanned", progress.DirectoriesScanned.Load())
+		}
+		gaba.ProcessMessage(message, gaba.ProcessMessageOptions{ShowProgressBar: true, Progress: progressBar}, func() (interface{}, error) {
+			progressBar.Store(progress.Fraction())
+			select {
+				case <-scanFinished:
+				case <-time.After(scanRefreshDelay):
+			}
+			progressBar.Store(progress.Fraction())
+			return nil, nil
+		})
+		select {
+			case <-scanFinished:
+				if progress.IsCancelled() {
+					utils.ShowTimedMessage("Decoration scan cancelled", shortMessageDelay)
+					return false
+				}
+				state.SetDecorationAggregations(consoleAggregation, directoryAggregation)
+				return true
+			default:
+		}
+	}
+}
+
+// ensureDecorationAggregations scans before a decoration browser draws, since every way back into one can
+// follow a change that cleared the aggregations. Cancelling the scan backs out to the screen that opened the browser
+func ensureDecorationAggregations(screen models.Screen) models.Screen {
+	db, isBrowser := screen.(ui.DecorationBrowser)
+	if !isBrowser || loadDecorationAggregations() {
+		return screen
+	}
+	if db.DecorationBrowserIndex == ui.DefaultDecorationBrowserIndex {
+		state.RemoveMenuPositions(1)
+	} else {
+		state.RemoveMenuPositions(2)
+	}
+	if db.SlotOptions != nil {
+		return *db.SlotOptions
+	}
+	return ui.InitDecorationOptions(db.RomDirectoryList, db.ListWallpaperSelected)
+}
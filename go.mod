@@ -7,6 +7,7 @@ require (
 	github.com/UncleJunVIP/nextui-pak-shared-functions v1.11.0
 	github.com/redria7/gabagool v0.0.92
 	github.com/spf13/viper v1.21.0
+	github.com/veandco/go-sdl2 v0.4.40
 	go.uber.org/atomic v1.11.0
 	go.uber.org/zap v1.27.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -28,7 +29,6 @@ require (
 	github.com/spf13/cast v1.10.0 // indirect
 	github.com/spf13/pflag v1.0.10 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
-	github.com/veandco/go-sdl2 v0.4.40 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/net v0.39.0 // indirect
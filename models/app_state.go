@@ -11,9 +11,13 @@ type AppState struct {
 
 	DecorationsAggregatedOnConsoles []ConsoleAggregation
 	DecorationsAggregatedOnDirectories []DirectoryAggregation
+	DecorationsAggregated bool	// Set by a finished scan, since a card without decorations scans to empty lists
+	DecorationGroups map[int][]DecorationGroup	// Regrouped aggregations, keyed by aggregation mode
 
 	ThemeCatalog	[]ThemeSummary
 
+	SearchQueries	map[string]string	// Active list searches, keyed by the list they filter
+
 	// GamePlayMap 	map[string][]PlayHistoryAggregate
 	// ConsolePlayMap 	map[string]int
 	// TotalPlay 		int
@@ -37,6 +41,12 @@ type Decoration struct {
 	DecorationPath	string	// For file magic + finding the decoration in either aggregation list
 	ConsoleName 	string	// For finding the decoration in the ConsoleAggregation list
 	DirectoryName 	string	// For finding the decoration in the DirectoryAggregation list
+	Width			int		// Image dimensions, read from the file header during aggregation
+	Height			int
+	HasAlpha		bool	// Wallpaper sized image has transparent pixels
+	ImageClass		string	// Likely use of the image: icon, wallpaper, list wallpaper, or unknown
+	SourcePath		string	// Decoration source the image was found under
+	ModTime			int64	// Unix seconds of the last modification, for recency grouping
 }
 
 type ConsoleAggregation struct {
@@ -45,6 +55,12 @@ type ConsoleAggregation struct {
 	DecorationList	[]Decoration
 }
 
+// DecorationGroup is a named grouping of decorations built from the directory aggregation for the extra browse modes
+type DecorationGroup struct {
+	GroupName		string
+	DecorationList	[]Decoration
+}
+
 type DirectoryAggregation struct {
 	DirectoryName	string
 	DecorationList 	[]Decoration
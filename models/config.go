@@ -9,6 +9,33 @@ import (
 type Config struct {
 	LogLevel        			string  `yaml:"log_level"`
 	DecorationAggregationType	int		`yaml:"decoration_aggregation_type"`
+	DecorationSuitabilityMode	int		`yaml:"decoration_suitability_mode"`
+	DecorationSources			[]DecorationSource	`yaml:"decoration_sources"`
+	TraversalRules				[]TraversalRule		`yaml:"traversal_rules,omitempty"`
+}
+
+// DecorationSource is a directory scanned for decorations. A nil source list in the config means the built in defaults
+type DecorationSource struct {
+	Path		string		`yaml:"path"`
+	TagMode		int			`yaml:"tag_mode"`
+	Exclusions	[]string	`yaml:"exclusions,omitempty"`
+}
+
+// TraversalRule decides how a directory is walked. Tag, PathPrefix and the depth bounds must all match when set.
+// Depth counts directories below PathPrefix, or below the walk's starting directory when no prefix is given
+type TraversalRule struct {
+	Scope		string	`yaml:"scope,omitempty"`
+	Tag			string	`yaml:"tag,omitempty"`
+	PathPrefix	string	`yaml:"path_prefix,omitempty"`
+	MinDepth	int		`yaml:"min_depth,omitempty"`
+	MaxDepth	int		`yaml:"max_depth,omitempty"`
+	Action		string	`yaml:"action"`
+}
+
+type DecorationSourceSelections struct {
+	TagMode			int
+	ExclusionAction	int
+	Remove			bool
 }
 
 func (c *Config) MarshalLogObject(enc zapcore.ObjectEncoder) error {
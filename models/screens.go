@@ -12,6 +12,38 @@ type ScreenName struct {
 	ManageThemeOptions,
 	ManageThemeComponents,
 	ManageThemeComponentOptions,
+	ThemeTargetPicker,
+	RecolorTheme,
+	AestheticTools,
+	CoverageReport,
+	ThemeCompareBase,
+	ThemeComparison,
+	ThemeComparisonDetails,
+	ThemeComposer,
+	ThemeEditor,
+	ThemeSlotOptions,
+	DecorationActions,
+	AddToThemeSelection,
+	AddToThemePicker,
+	ThemeSlotPicker,
+	BulkApplyMode,
+	BulkTargetPicker,
+	AutoDecorate,
+	AutoDecoratePlan,
+	ShuffleDecorations,
+	ShuffleConsolePicker,
+	ThemeRotation,
+	RotationThemePicker,
+	RotationEntryOptions,
+	Profiles,
+	ProfileOptions,
+	ComposeSourcePicker,
+	ComposeConsolePicker,
+	CollectionCollage,
+	CropDecoration,
+	DecorationSources,
+	DecorationSourceOptions,
+	DecorationSourcePicker,
 
 	Settings,
 	MainMenu sum.Int[ScreenName]
@@ -34,6 +34,13 @@ type ComponentOptionSelections struct{
 	OptionClear		bool
 	OptionPreserve	bool
 	OptionConfirm	bool
+	SelectedTargets	map[string]bool	// Consoles and menus to touch, keyed by target name. Nil means no restriction
+}
+
+// ComponentTarget is a console directory or system menu that theme components can be applied to
+type ComponentTarget struct {
+	TargetName	string
+	ConsoleTag	string
 }
 
 // CatalogData represents the structure of the catalog.json file
@@ -50,3 +57,121 @@ type CatalogItemInfo struct {
 	URL			string `json:"URL"`
 	LastUpdated	string `json:"last_updated"`
 }
+
+type RecolorSelections struct {
+	Mode			string
+	HueShift		int
+	TintName		string
+	TintStrength	int
+	PaletteName		string
+}
+
+type CollageSelections struct {
+	GridSize		int
+	Destination		Theme	// Empty theme targets the device itself
+	Overwrite		bool
+}
+
+type CropSelections struct {
+	XPercent		int
+	YPercent		int
+	SizePercent		int
+}
+
+// CoverageRow records whether each applicable component type is themed for one target. Missing keys do not apply
+type CoverageRow struct {
+	TargetName		string
+	TargetGroup		string
+	Coverage		map[string]bool
+}
+
+// DecorationComparison classifies one device target path between a theme and the current device or another theme
+type DecorationComparison struct {
+	TargetPath		string
+	ThemeImagePath	string
+	BaseImagePath	string
+	Status			string
+}
+
+type ComponentComparison struct {
+	ComponentName	string
+	Comparisons		[]DecorationComparison
+}
+
+// ThemeUpdateReport sums up one theme apply, clear, or save run
+type ThemeUpdateReport struct {
+	ModifyCount			int
+	UnchangedCount		int
+	LockedDecorations	[]string
+	Cancelled			bool
+}
+
+// ThemeRecipe records where a composed theme takes each component from so it can be rebuilt when its sources change.
+// Console sources replace the component sources for that console's icons and wallpapers
+type ThemeRecipe struct {
+	ThemeName	string				`json:"theme_name"`
+	Components	map[string]string	`json:"components"`
+	Consoles	map[string]string	`json:"consoles,omitempty"`
+}
+
+// ThemeSlot is one image a theme can hold: an icon, wallpaper, or list wallpaper for a single target or nested folder
+type ThemeSlot struct {
+	TargetName		string
+	TargetGroup		string
+	TargetPath		string
+	ParentPath		string
+	ComponentName	string
+	ComponentType	string
+	SlotPath		string	// Where an assigned image is written inside the theme
+	ImagePath		string	// The theme's current image for the slot. Empty when the slot is unfilled
+}
+
+// ThemeSlotAssignment pairs a decoration with the theme slot it is being added to
+type ThemeSlotAssignment struct {
+	Slot		ThemeSlot
+	SourcePath	string
+}
+
+type AutoDecorateSelections struct {
+	Policy			string
+	MissingOnly		bool
+}
+
+// DecorationPlanEntry is one folder decoration an auto decorate or shuffle would set: the folder, the chosen image, and
+// whether a decoration is already there
+type DecorationPlanEntry struct {
+	FolderPath			string
+	ImagePath			string
+	DestinationPath		string
+	Replaces			bool
+}
+
+// ShuffleSelections describes one shuffle. The same seed and selections pick the same images while the decorations
+// and rom folders are unchanged
+type ShuffleSelections struct {
+	ComponentTypes	[]string	`json:"component_types"`
+	MissingOnly		bool		`json:"missing_only"`
+	ConsoleTags		[]string	`json:"console_tags,omitempty"`	// Empty shuffles every console
+	Seed			int64		`json:"seed"`
+}
+
+// ThemeRotation is the list of themes and shuffles the rotate command steps through, along with where it is in the list
+type ThemeRotation struct {
+	Policy			string			`json:"policy"`
+	Entries			[]RotationEntry	`json:"entries"`
+	NextIndex		int				`json:"next_index"`
+	AppliedIndex	int				`json:"applied_index"`	// -1 until the first rotation
+	LastRotated		int64			`json:"last_rotated"`	// Unix seconds
+}
+
+// RotationEntry is a local theme or a shuffle. A shuffle with no seed draws a new one every time it comes up
+type RotationEntry struct {
+	ThemeName	string				`json:"theme_name,omitempty"`
+	Shuffle		*ShuffleSelections	`json:"shuffle,omitempty"`
+	StartHour	int					`json:"start_hour"`	// Used by the time of day policy
+}
+
+type RotationEntrySelections struct {
+	StartHour	int
+	Remove		bool
+}
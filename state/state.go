@@ -37,12 +37,7 @@ func GetAppState() *models.AppState {
 
 func CycleAggregationMode() {
 	temp := GetAppState()
-	switch temp.Config.DecorationAggregationType {
-		case utils.AggregateByConsole:
-			temp.Config.DecorationAggregationType = utils.AggregateByDirectory
-		case utils.AggregateByDirectory:
-			temp.Config.DecorationAggregationType = utils.AggregateByConsole
-	}
+	temp.Config.DecorationAggregationType = utils.NextAggregationMode(temp.Config.DecorationAggregationType)
 	UpdateAppState(temp)
 }
 
@@ -126,16 +121,48 @@ func GetCurrentMenuPosition() (int, int) {
 
 func GetDecorationAggregation() ([]models.ConsoleAggregation, []models.DirectoryAggregation) {
 	temp := GetAppState()
-	if temp.DecorationsAggregatedOnConsoles == nil {
+	if !temp.DecorationsAggregated {
 		updateDecorationAggregations()
 		temp = GetAppState()
 	}
 	return temp.DecorationsAggregatedOnConsoles, temp.DecorationsAggregatedOnDirectories
 }
 
+// GetDecorationGroups regroups the stored aggregations for browse modes beyond console and directory. Each mode is
+// grouped once and kept until the aggregations change
+func GetDecorationGroups(aggregationType int) []models.DecorationGroup {
+	_, directoryAggregation := GetDecorationAggregation()
+	temp := GetAppState()
+	if decorationGroups, grouped := temp.DecorationGroups[aggregationType]; grouped {
+		return decorationGroups
+	}
+	decorationGroups := utils.GroupDecorations(directoryAggregation, aggregationType, utils.GetDecorationSources(temp.Config))
+	if temp.DecorationGroups == nil {
+		temp.DecorationGroups = make(map[int][]models.DecorationGroup)
+	}
+	temp.DecorationGroups[aggregationType] = decorationGroups
+	UpdateAppState(temp)
+	return decorationGroups
+}
+
 func updateDecorationAggregations() {
 	temp := GetAppState()
-	temp.DecorationsAggregatedOnConsoles, temp.DecorationsAggregatedOnDirectories = utils.GenerateDecorationAggregations()
+	temp.DecorationsAggregatedOnConsoles, temp.DecorationsAggregatedOnDirectories = utils.GenerateDecorationAggregations(utils.GetDecorationSources(temp.Config), &utils.DecorationScanProgress{})
+	temp.DecorationsAggregated = true
+	temp.DecorationGroups = nil
+	UpdateAppState(temp)
+}
+
+func HasDecorationAggregations() bool {
+	return GetAppState().DecorationsAggregated
+}
+
+func SetDecorationAggregations(consoleAggregation []models.ConsoleAggregation, directoryAggregation []models.DirectoryAggregation) {
+	temp := GetAppState()
+	temp.DecorationsAggregatedOnConsoles = consoleAggregation
+	temp.DecorationsAggregatedOnDirectories = directoryAggregation
+	temp.DecorationsAggregated = true
+	temp.DecorationGroups = nil
 	UpdateAppState(temp)
 }
 
@@ -143,6 +170,21 @@ func ClearDecorationAggregations() {
 	temp := GetAppState()
 	temp.DecorationsAggregatedOnConsoles = nil
 	temp.DecorationsAggregatedOnDirectories = nil
+	temp.DecorationsAggregated = false
+	temp.DecorationGroups = nil
+	UpdateAppState(temp)
+}
+
+func GetSearchQuery(searchKey string) string {
+	return GetAppState().SearchQueries[searchKey]
+}
+
+func SetSearchQuery(searchKey string, query string) {
+	temp := GetAppState()
+	if temp.SearchQueries == nil {
+		temp.SearchQueries = make(map[string]string)
+	}
+	temp.SearchQueries[searchKey] = query
 	UpdateAppState(temp)
 }
 
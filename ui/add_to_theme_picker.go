@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"sort"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// AddToThemePicker chooses the local theme the selected decorations are added to
+type AddToThemePicker struct {
+	Selection	AddToThemeSelection
+	Decorations	[]models.Decoration
+}
+
+func InitAddToThemePicker(selection AddToThemeSelection, decorations []models.Decoration) AddToThemePicker {
+	return AddToThemePicker{
+		Selection:		selection,
+		Decorations:	decorations,
+	}
+}
+
+func (atp AddToThemePicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.AddToThemePicker
+}
+
+func (atp AddToThemePicker) Draw() (interface{}, int, error) {
+	title := "Add to Which Theme?"
+
+	// Every local theme is listed, including empty ones still being built
+	var menuItems []gaba.MenuItem
+	currentThemes := utils.GetDownloadedThemes()
+	themeKeys := make([]string, 0, len(currentThemes))
+	for key := range currentThemes {
+		themeKeys = append(themeKeys, key)
+	}
+	sort.Strings(themeKeys)
+	for _, key := range themeKeys {
+		theme := currentThemes[key]
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     theme.ThemeName,
+			Selected: false,
+			Focused:  false,
+			Metadata: theme,
+			ImageFilename: utils.GetPreviewPath(theme.ThemeName),
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No Local Themes Found"
+	options.EnableImages = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(models.Theme), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
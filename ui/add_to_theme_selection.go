@@ -0,0 +1,80 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// AddToThemeSelection picks the decorations to add to a theme, starting with the one the actions were opened on
+type AddToThemeSelection struct {
+	Actions	DecorationActions
+}
+
+func InitAddToThemeSelection(actions DecorationActions) AddToThemeSelection {
+	return AddToThemeSelection{
+		Actions:	actions,
+	}
+}
+
+func (ats AddToThemeSelection) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.AddToThemeSelection
+}
+
+func (ats AddToThemeSelection) Draw() (interface{}, int, error) {
+	title := "Choose Images to Add"
+
+	var menuItems []gaba.MenuItem
+	for _, decoration := range ats.Actions.Browser.CurrentDecorations() {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     decoration.DecorationName,
+			Selected: decoration.DecorationPath == ats.Actions.Decoration.DecorationPath,
+			Focused:  false,
+			Metadata: decoration,
+			ImageFilename: decoration.DecorationPath,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No Decorations Found"
+	options.EnableImages = true
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Toggle"},
+		{ButtonName: "Start", HelpText: "Confirm"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		var decorations []models.Decoration
+		for _, selectedItem := range selection.Unwrap().SelectedItems {
+			decorations = append(decorations, selectedItem.Metadata.(models.Decoration))
+		}
+		return decorations, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
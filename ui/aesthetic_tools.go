@@ -0,0 +1,103 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	CollectionCollageDisplayName	= "Generate Collection Icons"
+)
+
+type AestheticTools struct{}
+
+func InitAestheticTools() AestheticTools {
+	return AestheticTools{}
+}
+
+func (at AestheticTools) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.AestheticTools
+}
+
+func (at AestheticTools) Draw() (interface{}, int, error) {
+	title := AestheticToolsDisplayName
+
+	// Add items to menu
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     CollectionCollageDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: CollectionCollageDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     AutoDecorateDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: AutoDecorateDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     ShuffleDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: ShuffleDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     ThemeRotationDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: ThemeRotationDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     CoverageReportDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: CoverageReportDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     ComposeDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: ComposeDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     NewThemeDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: NewThemeDisplayName,
+	})
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	AutoDecorateDisplayName	= "Auto Decorate"
+	autoDecoratePolicyName	= "Choose"
+	autoDecorateModeName	= "Folders"
+)
+
+type AutoDecorate struct{}
+
+func InitAutoDecorate() AutoDecorate {
+	return AutoDecorate{}
+}
+
+func (ad AutoDecorate) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.AutoDecorate
+}
+
+func (ad AutoDecorate) Draw() (interface{}, int, error) {
+	var policyOptions []gaba.Option
+	for _, policy := range utils.AutoDecoratePolicies {
+		policyOptions = append(policyOptions, gaba.Option{DisplayName: policy, Value: policy})
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item: gaba.MenuItem{Text: autoDecoratePolicyName},
+			Options: policyOptions,
+		},
+		{
+			Item: gaba.MenuItem{Text: autoDecorateModeName},
+			Options: []gaba.Option{
+				{DisplayName: "Missing Wallpapers", Value: true},
+				{DisplayName: "All", Value: false},
+			},
+		},
+	}
+
+	footerHelpItems := []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "←→", HelpText: "Cycle"},
+		{ButtonName: "Start", HelpText: "Preview"},
+	}
+
+	// Wait for results
+	result, err := gaba.OptionsList("Auto Decorate Rom Folders", items, footerHelpItems)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if result.IsSome() {
+		selections := models.AutoDecorateSelections{}
+		for _, option := range result.Unwrap().Items {
+			value := option.Options[option.SelectedOption].Value
+			switch option.Item.Text {
+				case autoDecoratePolicyName:
+					selections.Policy = value.(string)
+				case autoDecorateModeName:
+					selections.MissingOnly = value.(bool)
+			}
+		}
+		return selections, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
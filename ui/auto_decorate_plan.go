@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"path/filepath"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// AutoDecoratePlan previews the wallpaper chosen for each folder. Every entry starts selected, and clearing one leaves
+// that folder untouched
+type AutoDecoratePlan struct {
+	Selections	models.AutoDecorateSelections
+	Plan		[]models.DecorationPlanEntry
+}
+
+func InitAutoDecoratePlan(selections models.AutoDecorateSelections, plan []models.DecorationPlanEntry) AutoDecoratePlan {
+	return AutoDecoratePlan{
+		Selections:	selections,
+		Plan:		plan,
+	}
+}
+
+func (adp AutoDecoratePlan) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.AutoDecoratePlan
+}
+
+func (adp AutoDecoratePlan) Draw() (interface{}, int, error) {
+	title := adp.Selections.Policy
+
+	var menuItems []gaba.MenuItem
+	for _, entry := range adp.Plan {
+		text := filepath.Base(entry.FolderPath) + ": " + filepath.Base(entry.ImagePath)
+		if entry.Replaces {
+			text = text + " [replace]"
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: true,
+			Focused:  false,
+			Metadata: entry,
+			BackgroundFilename: entry.ImagePath,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No folders to decorate!"
+	options.EnableImages = true
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Toggle"},
+		{ButtonName: "Start", HelpText: "Apply"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		var plan []models.DecorationPlanEntry
+		for _, selectedItem := range selection.Unwrap().SelectedItems {
+			plan = append(plan, selectedItem.Metadata.(models.DecorationPlanEntry))
+		}
+		return plan, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	BulkChooseFoldersName	= "Choose Folders"
+	BulkSubfoldersName		= "All Subfolders"
+	BulkConsoleTagName		= "All Consoles Tagged"
+	BulkMissingName			= "All Consoles Without"
+)
+
+// BulkApplyMode chooses how the folders for applying one decoration to many are gathered
+type BulkApplyMode struct {
+	Actions	DecorationActions
+}
+
+func InitBulkApplyMode(actions DecorationActions) BulkApplyMode {
+	return BulkApplyMode{
+		Actions:	actions,
+	}
+}
+
+func (bam BulkApplyMode) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.BulkApplyMode
+}
+
+// CurrentFolder gives the folder the decoration options were opened on
+func (bam BulkApplyMode) CurrentFolder() string {
+	directoryList := bam.Actions.Browser.RomDirectoryList
+	return directoryList[len(directoryList) - 1].Path
+}
+
+// BrowsedFolder gives the folder whose contents the directory browser was showing
+func (bam BulkApplyMode) BrowsedFolder() string {
+	directoryList := bam.Actions.Browser.RomDirectoryList
+	if len(directoryList) > 1 {
+		return directoryList[len(directoryList) - 2].Path
+	}
+	return directoryList[0].Path
+}
+
+// ConsoleTag gives the tag of the current folder, falling back to the console the decoration came from
+func (bam BulkApplyMode) ConsoleTag() string {
+	if consoleTag := utils.FindConsoleTag(bam.CurrentFolder()); consoleTag != "" {
+		return consoleTag
+	}
+	return utils.FindConsoleTag(bam.Actions.Decoration.ConsoleName)
+}
+
+func (bam BulkApplyMode) Draw() (interface{}, int, error) {
+	componentType := bam.Actions.Browser.ComponentType()
+	title := "Apply " + componentType + " To"
+
+	// Add items to menu
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     BulkChooseFoldersName,
+		Selected: false,
+		Focused:  false,
+		Metadata: BulkChooseFoldersName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     BulkSubfoldersName + " of " + bam.Actions.Browser.RomDirectoryList[len(bam.Actions.Browser.RomDirectoryList) - 1].DisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: BulkSubfoldersName,
+	})
+	if consoleTag := bam.ConsoleTag(); consoleTag != "" {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     BulkConsoleTagName + " " + consoleTag,
+			Selected: false,
+			Focused:  false,
+			Metadata: BulkConsoleTagName,
+		})
+	}
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     BulkMissingName + " " + componentType,
+		Selected: false,
+		Focused:  false,
+		Metadata: BulkMissingName,
+	})
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
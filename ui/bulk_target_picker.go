@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"path/filepath"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// BulkTargetPicker confirms the folders a decoration is applied to. Gathered sets start fully selected, while folders
+// chosen by hand start with only the current folder
+type BulkTargetPicker struct {
+	Mode		BulkApplyMode
+	Folders		[]string
+	SelectAll	bool
+}
+
+func InitBulkTargetPicker(mode BulkApplyMode, folders []string, selectAll bool) BulkTargetPicker {
+	return BulkTargetPicker{
+		Mode:		mode,
+		Folders:	folders,
+		SelectAll:	selectAll,
+	}
+}
+
+func (btp BulkTargetPicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.BulkTargetPicker
+}
+
+func (btp BulkTargetPicker) Draw() (interface{}, int, error) {
+	title := "Choose Folders"
+
+	componentType := btp.Mode.Actions.Browser.ComponentType()
+	var menuItems []gaba.MenuItem
+	for _, folderPath := range btp.Folders {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     filepath.Base(folderPath),
+			Selected: btp.SelectAll || folderPath == btp.Mode.CurrentFolder(),
+			Focused:  false,
+			Metadata: folderPath,
+			ImageFilename: utils.FolderDecorationPath(folderPath, componentType),
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No folders found!"
+	options.EnableImages = true
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Toggle"},
+		{ButtonName: "Start", HelpText: "Apply"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		var folders []string
+		for _, selectedItem := range selection.Unwrap().SelectedItems {
+			folders = append(folders, selectedItem.Metadata.(string))
+		}
+		return folders, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
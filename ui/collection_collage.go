@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"sort"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	collageGridName			= "Grid Size"
+	collageDestinationName	= "Save To"
+	collageModeName			= "Existing Icons"
+)
+
+type CollectionCollage struct{}
+
+func InitCollectionCollage() CollectionCollage {
+	return CollectionCollage{}
+}
+
+func (cc CollectionCollage) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.CollectionCollage
+}
+
+func (cc CollectionCollage) Draw() (interface{}, int, error) {
+	// Destinations are the device itself or any local theme
+	destinationOptions := []gaba.Option{
+		{DisplayName: "Device", Value: models.Theme{}},
+	}
+	currentThemes := utils.GetDownloadedThemes()
+	themeKeys := make([]string, 0, len(currentThemes))
+	for key := range currentThemes {
+		themeKeys = append(themeKeys, key)
+	}
+	sort.Strings(themeKeys)
+	for _, key := range themeKeys {
+		if currentThemes[key].ContainsTheme {
+			destinationOptions = append(destinationOptions, gaba.Option{DisplayName: key, Value: currentThemes[key]})
+		}
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item: gaba.MenuItem{Text: collageGridName},
+			Options: []gaba.Option{
+				{DisplayName: "2x2", Value: 2},
+				{DisplayName: "3x3", Value: 3},
+			},
+		},
+		{
+			Item: gaba.MenuItem{Text: collageDestinationName},
+			Options: destinationOptions,
+		},
+		{
+			Item: gaba.MenuItem{Text: collageModeName},
+			Options: []gaba.Option{
+				{DisplayName: "Keep", Value: false},
+				{DisplayName: "Overwrite", Value: true},
+			},
+		},
+	}
+
+	footerHelpItems := []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "←→", HelpText: "Cycle"},
+		{ButtonName: "Start", HelpText: "Generate"},
+	}
+
+	// Wait for results
+	result, err := gaba.OptionsList("Collection Icon Collages", items, footerHelpItems)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if result.IsSome() {
+		selections := models.CollageSelections{}
+		for _, option := range result.Unwrap().Items {
+			value := option.Options[option.SelectedOption].Value
+			switch option.Item.Text {
+				case collageGridName:
+					selections.GridSize = value.(int)
+				case collageDestinationName:
+					selections.Destination = value.(models.Theme)
+				case collageModeName:
+					selections.Overwrite = value.(bool)
+			}
+		}
+		return selections, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// ComposeConsolePicker chooses a console to give its own source in a recipe
+type ComposeConsolePicker struct {
+	Recipe	models.ThemeRecipe
+}
+
+func InitComposeConsolePicker(recipe models.ThemeRecipe) ComposeConsolePicker {
+	return ComposeConsolePicker{
+		Recipe:	recipe,
+	}
+}
+
+func (ccp ComposeConsolePicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ComposeConsolePicker
+}
+
+func (ccp ComposeConsolePicker) Draw() (interface{}, int, error) {
+	title := ComposeAddConsoleName
+
+	// Console directories sharing a tag share a source, so each tag is listed once
+	targets, err := utils.GetComponentTargets(false)
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+	var menuItems []gaba.MenuItem
+	listedTags := make(map[string]bool)
+	for _, target := range targets {
+		if target.ConsoleTag == "" || listedTags[target.ConsoleTag] {
+			continue
+		}
+		if _, exists := ccp.Recipe.Consoles[target.ConsoleTag]; exists {
+			continue
+		}
+		listedTags[target.ConsoleTag] = true
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     target.TargetName,
+			Selected: false,
+			Focused:  false,
+			Metadata: target.ConsoleTag,
+		})
+	}
+	if len(menuItems) == 0 {
+		gaba.ConfirmationMessage("No consoles left to give their own source.", []gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Back"},
+		}, gaba.MessageOptions{})
+		return nil, utils.ExitCodeCancel, nil
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"sort"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// ComposeSourcePicker chooses the theme a recipe takes one component, or one console, from
+type ComposeSourcePicker struct {
+	Recipe			models.ThemeRecipe
+	ComponentName	string
+	ConsoleTag		string
+}
+
+func InitComposeSourcePicker(recipe models.ThemeRecipe, componentName string, consoleTag string) ComposeSourcePicker {
+	return ComposeSourcePicker{
+		Recipe:			recipe,
+		ComponentName:	componentName,
+		ConsoleTag:		consoleTag,
+	}
+}
+
+func (csp ComposeSourcePicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ComposeSourcePicker
+}
+
+func (csp ComposeSourcePicker) Draw() (interface{}, int, error) {
+	title := csp.ComponentName + " Source"
+	if csp.ConsoleTag != "" {
+		title = csp.ConsoleTag + " Consoles Source"
+	}
+
+	// None clears the source. Only themes with something to offer are listed, and the composed theme never sources itself
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     composeNoSourceName,
+		Selected: false,
+		Focused:  false,
+		Metadata: "",
+	})
+	currentThemes := utils.GetDownloadedThemes()
+	themeKeys := make([]string, 0, len(currentThemes))
+	for key := range currentThemes {
+		themeKeys = append(themeKeys, key)
+	}
+	sort.Strings(themeKeys)
+	for _, key := range themeKeys {
+		theme := currentThemes[key]
+		if !theme.ContainsTheme || theme.ThemeName == csp.Recipe.ThemeName {
+			continue
+		}
+		if csp.ComponentName != "" && !utils.ThemeSupportsComponent(theme, csp.ComponentName) {
+			continue
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     theme.ThemeName,
+			Selected: false,
+			Focused:  false,
+			Metadata: theme.ThemeName,
+			ImageFilename: utils.GetPreviewPath(theme.ThemeName),
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EnableImages = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Use"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	CoverageReportDisplayName	= "Coverage Report"
+	coverageMissingMark			= "_"
+	coverageUnusedMark			= "-"
+)
+
+// coverageColumnMarks holds the letter shown for each themed matrix column
+var coverageColumnMarks = map[string]string{
+	utils.ComponentTypeIcon:			"I",
+	utils.ComponentTypeWallpaper:		"W",
+	utils.ComponentTypeListWallpaper:	"L",
+}
+
+type CoverageReport struct {
+	Theme	models.Theme
+}
+
+func InitCoverageReport(theme models.Theme) CoverageReport {
+	return CoverageReport{
+		Theme:	theme,
+	}
+}
+
+func (cr CoverageReport) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.CoverageReport
+}
+
+func (cr CoverageReport) Draw() (interface{}, int, error) {
+	themeName := cr.Theme.ThemeName
+	if utils.IsCurrentTheme(cr.Theme) {
+		themeName = "Current Theme"
+	}
+
+	rows, err := utils.GetCoverageReport(cr.Theme)
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+	title := fmt.Sprintf("%s Coverage %d%%", themeName, utils.CoveragePercent(rows, ""))
+
+	// Column totals come first, then one row per target in group order
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     fmt.Sprintf("Icons %d%%  Wallpapers %d%%  Lists %d%%", utils.CoveragePercent(rows, utils.ComponentTypeIcon), utils.CoveragePercent(rows, utils.ComponentTypeWallpaper), utils.CoveragePercent(rows, utils.ComponentTypeListWallpaper)),
+		Selected: false,
+		Focused:  false,
+		Metadata: nil,
+	})
+	for _, row := range rows {
+		marks := ""
+		for _, componentType := range utils.CoverageComponentTypes {
+			covered, applicable := row.Coverage[componentType]
+			switch {
+				case !applicable:
+					marks = marks + coverageUnusedMark
+				case covered:
+					marks = marks + coverageColumnMarks[componentType]
+				default:
+					marks = marks + coverageMissingMark
+			}
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("[%s] %3d%%  %s", marks, utils.CoveragePercent([]models.CoverageRow{row}, ""), row.TargetName),
+			Selected: false,
+			Focused:  false,
+			Metadata: row,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Details"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = "Coverage Report"
+	options.HelpText = []string{
+		"• Columns are Icon, Wallpaper, and List Wallpaper",
+		"• A letter means the theme has that image",
+		"• _ means it is missing, - means the target does not use it",
+		"• Percentages count only the images a target uses",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"image"
+	"time"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"github.com/veandco/go-sdl2/img"
+	"github.com/veandco/go-sdl2/sdl"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	cropPositionStep	= 2
+	cropSizeStep		= 5
+	cropMinimumSize		= 20
+	cropOutlineWidth	= 4
+	cropFrameDelay		= 16
+	cropHelpDelay		= 2 * time.Second
+)
+
+var DefaultCropSelections = models.CropSelections{
+	XPercent:		50,
+	YPercent:		50,
+	SizePercent:	100,
+}
+
+type CropDecoration struct {
+	RomDirectoryList		[]shared.RomDirectory
+	ListWallpaperSelected	bool
+	DecorationType			string
+	DecorationBrowserIndex	int
+	Decoration				models.Decoration
+	Selections				models.CropSelections
+}
+
+func InitCropDecoration(romDirectoryList []shared.RomDirectory, listWallpaperSelected bool, decorationType string, decorationBrowserIndex int, decoration models.Decoration, selections models.CropSelections) CropDecoration {
+	return CropDecoration{
+		RomDirectoryList:		romDirectoryList,
+		ListWallpaperSelected:	listWallpaperSelected,
+		DecorationType:			decorationType,
+		DecorationBrowserIndex:	decorationBrowserIndex,
+		Decoration:				decoration,
+		Selections:				selections,
+	}
+}
+
+func (cd CropDecoration) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.CropDecoration
+}
+
+// Draw shows the image with the selection drawn over it. The D-pad moves the selection and the shoulder buttons
+// resize it. Icons are locked square and wallpapers keep the screen's shape, so there is no free aspect ratio
+func (cd CropDecoration) Draw() (interface{}, int, error) {
+	lockSquare := cd.DecorationType == SelectIconName
+	if cd.Selections == DefaultCropSelections {
+		utils.ShowTimedMessage("D-Pad: Move    L / R: Resize\nA: Preview    B: Cancel", cropHelpDelay)
+	}
+
+	window := gaba.GetWindow()
+	renderer := window.Renderer
+	texture, err := img.LoadTexture(renderer, cd.Decoration.DecorationPath)
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+	defer texture.Destroy()
+	_, _, imageWidth, imageHeight, err := texture.Query()
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Fit the whole image on screen, centered
+	screenWidth := window.GetWidth()
+	screenHeight := window.GetHeight()
+	scale := min(float64(screenWidth) / float64(imageWidth), float64(screenHeight) / float64(imageHeight))
+	imageArea := sdl.Rect{
+		W:	int32(float64(imageWidth) * scale),
+		H:	int32(float64(imageHeight) * scale),
+	}
+	imageArea.X = (screenWidth - imageArea.W) / 2
+	imageArea.Y = (screenHeight - imageArea.H) / 2
+
+	selections := cd.Selections
+	processor := gaba.GetInputProcessor()
+	for {
+		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+			if _, quit := event.(*sdl.QuitEvent); quit {
+				return nil, utils.ExitCodeCancel, nil
+			}
+			inputEvent := processor.ProcessSDLEvent(event)
+			if inputEvent == nil || !inputEvent.Pressed {
+				continue
+			}
+			switch inputEvent.Button {
+				case gaba.ButtonLeft:
+					selections.XPercent = max(0, selections.XPercent - cropPositionStep)
+				case gaba.ButtonRight:
+					selections.XPercent = min(100, selections.XPercent + cropPositionStep)
+				case gaba.ButtonUp:
+					selections.YPercent = max(0, selections.YPercent - cropPositionStep)
+				case gaba.ButtonDown:
+					selections.YPercent = min(100, selections.YPercent + cropPositionStep)
+				case gaba.ButtonL1:
+					selections.SizePercent = max(cropMinimumSize, selections.SizePercent - cropSizeStep)
+				case gaba.ButtonR1:
+					selections.SizePercent = min(100, selections.SizePercent + cropSizeStep)
+				case gaba.ButtonA, gaba.ButtonStart:
+					return selections, utils.ExitCodeSelect, nil
+				case gaba.ButtonB:
+					return nil, utils.ExitCodeCancel, nil
+			}
+		}
+
+		// The selection is located exactly as the crop will be, then scaled onto the screen
+		cropRectangle := utils.GetCropRectangle(image.Rect(0, 0, int(imageWidth), int(imageHeight)), selections, lockSquare)
+		selectionArea := sdl.Rect{
+			X:	imageArea.X + int32(float64(cropRectangle.Min.X) * scale),
+			Y:	imageArea.Y + int32(float64(cropRectangle.Min.Y) * scale),
+			W:	int32(float64(cropRectangle.Dx()) * scale),
+			H:	int32(float64(cropRectangle.Dy()) * scale),
+		}
+		drawCropSelection(renderer, texture, imageArea, selectionArea)
+		sdl.Delay(cropFrameDelay)
+	}
+}
+
+// drawCropSelection shades the image outside the selection and outlines it
+func drawCropSelection(renderer *sdl.Renderer, texture *sdl.Texture, imageArea sdl.Rect, selectionArea sdl.Rect) {
+	renderer.SetDrawColor(0, 0, 0, 255)
+	renderer.Clear()
+	renderer.Copy(texture, nil, &imageArea)
+
+	renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	renderer.SetDrawColor(0, 0, 0, 160)
+	for _, area := range []sdl.Rect{
+		{X: imageArea.X, Y: imageArea.Y, W: imageArea.W, H: selectionArea.Y - imageArea.Y},
+		{X: imageArea.X, Y: selectionArea.Y + selectionArea.H, W: imageArea.W, H: imageArea.Y + imageArea.H - selectionArea.Y - selectionArea.H},
+		{X: imageArea.X, Y: selectionArea.Y, W: selectionArea.X - imageArea.X, H: selectionArea.H},
+		{X: selectionArea.X + selectionArea.W, Y: selectionArea.Y, W: imageArea.X + imageArea.W - selectionArea.X - selectionArea.W, H: selectionArea.H},
+	} {
+		renderer.FillRect(&area)
+	}
+
+	renderer.SetDrawColor(255, 255, 255, 255)
+	for _, area := range []sdl.Rect{
+		{X: selectionArea.X, Y: selectionArea.Y, W: selectionArea.W, H: cropOutlineWidth},
+		{X: selectionArea.X, Y: selectionArea.Y + selectionArea.H - cropOutlineWidth, W: selectionArea.W, H: cropOutlineWidth},
+		{X: selectionArea.X, Y: selectionArea.Y, W: cropOutlineWidth, H: selectionArea.H},
+		{X: selectionArea.X + selectionArea.W - cropOutlineWidth, Y: selectionArea.Y, W: cropOutlineWidth, H: selectionArea.H},
+	} {
+		renderer.FillRect(&area)
+	}
+	renderer.Present()
+}
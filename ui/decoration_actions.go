@@ -0,0 +1,88 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	AddToThemeName			= "Add to Theme"
+	ApplyToFoldersName		= "Apply to Many Folders"
+	DeleteDecorationName	= "Delete"
+)
+
+// DecorationActions offers what else can be done with a decoration while browsing, besides applying it
+type DecorationActions struct {
+	Browser		DecorationBrowser
+	Decoration	models.Decoration
+}
+
+func InitDecorationActions(browser DecorationBrowser, decoration models.Decoration) DecorationActions {
+	return DecorationActions{
+		Browser:	browser,
+		Decoration:	decoration,
+	}
+}
+
+func (da DecorationActions) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.DecorationActions
+}
+
+func (da DecorationActions) Draw() (interface{}, int, error) {
+	title := da.Decoration.DecorationName
+
+	// Add items to menu
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     AddToThemeName,
+		Selected: false,
+		Focused:  false,
+		Metadata: AddToThemeName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     ApplyToFoldersName,
+		Selected: false,
+		Focused:  false,
+		Metadata: ApplyToFoldersName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     DeleteDecorationName,
+		Selected: false,
+		Focused:  false,
+		Metadata: DeleteDecorationName,
+	})
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
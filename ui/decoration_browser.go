@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"nextui-aesthetics/models"
 	"nextui-aesthetics/state"
 	"nextui-aesthetics/utils"
@@ -18,6 +19,7 @@ type DecorationBrowser struct {
 	ListWallpaperSelected	bool
 	DecorationType			string
 	DecorationBrowserIndex	int
+	SlotOptions				*ThemeSlotOptions	// Set when picking an image for a theme slot instead of the device
 }
 
 func InitDecorationBrowser(romDirectoryList []shared.RomDirectory, listWallpaperSelected bool, decorationType string, decorationbrowserIndex int) DecorationBrowser {
@@ -29,10 +31,35 @@ func InitDecorationBrowser(romDirectoryList []shared.RomDirectory, listWallpaper
 	}
 }
 
+// InitThemeSlotDecorationBrowser browses decorations for a theme editor slot. Previews use the slot's device target
+func InitThemeSlotDecorationBrowser(slotOptions ThemeSlotOptions, decorationBrowserIndex int) DecorationBrowser {
+	slot := slotOptions.Slot
+	decorationType := SelectIconName
+	switch slot.ComponentType {
+		case utils.ComponentTypeWallpaper:
+			decorationType = SelectWallpaperName
+		case utils.ComponentTypeListWallpaper:
+			decorationType = SelectListWallpaperName
+	}
+	return DecorationBrowser{
+		RomDirectoryList:	[]shared.RomDirectory{
+			shared.RomDirectory{DisplayName: slot.ParentPath, Path: slot.ParentPath},
+			shared.RomDirectory{DisplayName: slot.TargetName, Path: slot.TargetPath},
+		},
+		DecorationType:			decorationType,
+		DecorationBrowserIndex:	decorationBrowserIndex,
+		SlotOptions:			&slotOptions,
+	}
+}
+
 func (db DecorationBrowser) Name() sum.Int[models.ScreenName] {
 	return models.ScreenNames.DecorationBrowser
 }
 
+func (db DecorationBrowser) SearchKey() string {
+	return fmt.Sprintf("decorations/%d/%d", state.GetAppState().Config.DecorationAggregationType, db.DecorationBrowserIndex)
+}
+
 func (db DecorationBrowser) Draw() (item interface{}, exitCode int, e error) {
 	//logger := common.GetLoggerInstance()
 	topLevel := false
@@ -45,11 +72,20 @@ func (db DecorationBrowser) Draw() (item interface{}, exitCode int, e error) {
 	var menuItems []gaba.MenuItem
 	var parentAggName string
 	aggregationType := state.GetAppState().Config.DecorationAggregationType
-	if aggregationType == utils.AggregateByConsole {
-		menuItems, parentAggName = db.genConsoleMenuItems()
+	switch aggregationType {
+		case utils.AggregateByConsole:
+			menuItems, parentAggName = db.genConsoleMenuItems()
+		case utils.AggregateByDirectory:
+			menuItems, parentAggName = db.genDirectoryMenuItems()
+		default:
+			menuItems, parentAggName = db.genGroupMenuItems(aggregationType)
 	}
-	if aggregationType == utils.AggregateByDirectory {
-		menuItems, parentAggName = db.genDirectoryMenuItems()
+
+	// Filter by search
+	searchQuery := state.GetSearchQuery(db.SearchKey())
+	menuItems = filterMenuItemsBySearch(menuItems, searchQuery, decorationSearchFields)
+	if len(menuItems) > 0 || searchQuery != "" {
+		menuItems = append([]gaba.MenuItem{genSearchMenuItem(searchQuery)}, menuItems...)
 	}
 
 	// Set options
@@ -79,7 +115,7 @@ func (db DecorationBrowser) Draw() (item interface{}, exitCode int, e error) {
 
 	// Set footers
 	selectText := "Apply"
-	actionText := "Delete"
+	actionText := "Actions"
 	if topLevel {
 		selectText = "Open"
 		actionText = "Swap Aggregation"
@@ -89,19 +125,31 @@ func (db DecorationBrowser) Draw() (item interface{}, exitCode int, e error) {
 		{ButtonName: "A", HelpText: selectText},
 		{ButtonName: "X", HelpText: actionText},
 	}
+	// Theme slots only take a copy, so there is nothing to delete from here
+	if db.SlotOptions != nil && !topLevel {
+		options.EnableAction = false
+		options.FooterHelpItems = []gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Back"},
+			{ButtonName: "A", HelpText: "Use"},
+		}
+	}
 
 	// Set Help
 	options.EnableHelp = true
 	options.HelpTitle = "Decoration List Controls"
 	helpA := "Open confirmation screen to apply the selected decoration"
-	helpX := "Open confirmation screen to delete the selected decoration"
+	helpX := "Add the selected decoration to a theme, or delete it"
 	if topLevel {
 		helpA = "Open selected aggregation to view available decorations"
-		helpX = "Change aggregation style to view console or directory groupings"
+		helpX = "Change aggregation style to group by directory, console, source, theme, image size, or recent changes"
+	} else if db.SlotOptions != nil {
+		helpA = "Copy the selected decoration into the theme slot"
+		helpX = "Unavailable while editing a theme"
 	}
 	options.HelpText = []string{
 		"• A: " + helpA,
 		"• X: " + helpX,
+		"• Search: Filter by decoration, console, or directory name",
 	}
 
 	// Wait for results
@@ -117,6 +165,9 @@ func (db DecorationBrowser) Draw() (item interface{}, exitCode int, e error) {
 		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
 		exit_code := utils.ExitCodeAction
 		metadata := selection.Unwrap().SelectedItem.Metadata
+		if metadata == SearchItemName {
+			return nil, utils.ExitCodeSearch, nil
+		}
 		if !selection.Unwrap().ActionTriggered {
 			exit_code = utils.ExitCodeSelect
 		}
@@ -172,7 +223,8 @@ func (db DecorationBrowser) genConsoleMenuItems() ([]gaba.MenuItem, string) {
 		menuItems = append(menuItems, nonCurrentConsoleList...)
 	} else {
 		parentAggName = decorationAggregation[db.DecorationBrowserIndex].ConsoleName
-		for _, decoration := range decorationAggregation[db.DecorationBrowserIndex].DecorationList {
+		decorationList := db.arrangeDecorations(decorationAggregation[db.DecorationBrowserIndex].DecorationList)
+		for _, decoration := range decorationList {
 			wallpaperPath := ""
 			iconPath := ""
 			switch db.DecorationType {
@@ -250,6 +302,63 @@ func (db DecorationBrowser) genDirectoryMenuItems() ([]gaba.MenuItem, string) {
 			parentAggName = decorationAggregation[db.DecorationBrowserIndex].DirectoryName
 			decorationList = decorationAggregation[db.DecorationBrowserIndex].DecorationList
 		}
+		decorationList = db.arrangeDecorations(decorationList)
+		for _, decoration := range decorationList {
+			wallpaperPath := ""
+			iconPath := ""
+			switch db.DecorationType {
+				case SelectIconName:
+					iconPath = decoration.DecorationPath
+					wallpaperPath = currentWallpaperPath
+				case SelectWallpaperName:
+					iconPath = currentIconPath
+					wallpaperPath = decoration.DecorationPath
+				case SelectListWallpaperName:
+					wallpaperPath = decoration.DecorationPath
+			}
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text:     decoration.DecorationName,
+				Selected: false,
+				Focused:  false,
+				Metadata: decoration,
+				ImageFilename: iconPath,
+				BackgroundFilename: wallpaperPath,
+			})
+		}
+	}
+	return menuItems, parentAggName
+}
+
+func (db DecorationBrowser) genGroupMenuItems(aggregationType int) ([]gaba.MenuItem, string) {
+	var menuItems []gaba.MenuItem
+	parentAggName := ""
+	decorationGroups := state.GetDecorationGroups(aggregationType)
+	topLevel := false
+	if db.DecorationBrowserIndex == DefaultDecorationBrowserIndex {
+		topLevel = true
+	}
+	_, currentPath, parentPath := utils.GetCurrentDecorationDetails(db.RomDirectoryList)
+	currentDirectory := db.RomDirectoryList[len(db.RomDirectoryList) - 1]
+	currentWallpaperPath := utils.GetWallpaperPath(currentPath, parentPath)
+	currentIconPath := utils.GetIconPath(parentPath, currentDirectory.Path)
+	if db.DecorationType == SelectListWallpaperName {
+		currentIconPath = ""
+		currentWallpaperPath = utils.GetListWallpaperPath(currentPath)
+	}
+	if topLevel {
+		for index, group := range decorationGroups {
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text: group.GroupName,
+				Selected: false,
+				Focused:  false,
+				Metadata: index,
+				ImageFilename: currentIconPath,
+				BackgroundFilename: currentWallpaperPath,
+			})
+		}
+	} else if db.DecorationBrowserIndex < len(decorationGroups) {
+		parentAggName = decorationGroups[db.DecorationBrowserIndex].GroupName
+		decorationList := db.arrangeDecorations(decorationGroups[db.DecorationBrowserIndex].DecorationList)
 		for _, decoration := range decorationList {
 			wallpaperPath := ""
 			iconPath := ""
@@ -276,6 +385,71 @@ func (db DecorationBrowser) genDirectoryMenuItems() ([]gaba.MenuItem, string) {
 	return menuItems, parentAggName
 }
 
+// CurrentDecorations lists the decorations of the open aggregation in the order the browser shows them
+func (db DecorationBrowser) CurrentDecorations() []models.Decoration {
+	var decorationList []models.Decoration
+	consoleAggregation, decorationAggregation := state.GetDecorationAggregation()
+	aggregationType := state.GetAppState().Config.DecorationAggregationType
+	switch {
+		case db.DecorationBrowserIndex == DefaultDecorationBrowserIndex:
+			return nil
+		case aggregationType == utils.AggregateByConsole:
+			decorationList = consoleAggregation[db.DecorationBrowserIndex].DecorationList
+		case aggregationType == utils.AggregateByDirectory && db.DecorationBrowserIndex < 0:
+			decorationList = consoleAggregation[flipIndex(db.DecorationBrowserIndex)].DecorationList
+		case aggregationType == utils.AggregateByDirectory:
+			decorationList = decorationAggregation[db.DecorationBrowserIndex].DecorationList
+		default:
+			decorationGroups := state.GetDecorationGroups(aggregationType)
+			if db.DecorationBrowserIndex < len(decorationGroups) {
+				decorationList = decorationGroups[db.DecorationBrowserIndex].DecorationList
+			}
+	}
+	return db.filterDecorationsBySearch(db.arrangeDecorations(decorationList))
+}
+
+// filterDecorationsBySearch applies the open list's search the same way Draw does, so actions on the whole list
+// only see what is on screen
+func (db DecorationBrowser) filterDecorationsBySearch(decorationList []models.Decoration) []models.Decoration {
+	searchQuery := state.GetSearchQuery(db.SearchKey())
+	if searchQuery == "" {
+		return decorationList
+	}
+	menuItems := make([]gaba.MenuItem, 0, len(decorationList))
+	for _, decoration := range decorationList {
+		menuItems = append(menuItems, gaba.MenuItem{Text: decoration.DecorationName, Metadata: decoration})
+	}
+	var filteredList []models.Decoration
+	for _, menuItem := range filterMenuItemsBySearch(menuItems, searchQuery, decorationSearchFields) {
+		filteredList = append(filteredList, menuItem.Metadata.(models.Decoration))
+	}
+	return filteredList
+}
+
+// decorationSearchFields matches aggregations on their console or directory name, decorations on every name they carry
+func decorationSearchFields(menuItem gaba.MenuItem) []string {
+	if decoration, isDecoration := menuItem.Metadata.(models.Decoration); isDecoration {
+		return []string{decoration.DecorationName, decoration.ConsoleName, decoration.DirectoryName}
+	}
+	return []string{menuItem.Text}
+}
+
+// arrangeDecorations orders the list so images sized for the selected decoration type come first, or hides the rest
+func (db DecorationBrowser) arrangeDecorations(decorationList []models.Decoration) []models.Decoration {
+	return utils.ArrangeDecorationsBySuitability(decorationList, db.ComponentType(), state.GetAppState().Config.DecorationSuitabilityMode)
+}
+
+// ComponentType gives the component type of the decoration being chosen
+func (db DecorationBrowser) ComponentType() string {
+	switch db.DecorationType {
+		case SelectWallpaperName:
+			return utils.ComponentTypeWallpaper
+		case SelectListWallpaperName:
+			return utils.ComponentTypeListWallpaper
+	}
+	return utils.ComponentTypeIcon
+}
+
 func flipIndex(index int) int {
 	if index >= 0 {
 		return (index * -1) - 2
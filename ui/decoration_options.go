@@ -18,6 +18,12 @@ const (
 	selectDefaultWallpaperName = 	"Select Default Wallpaper"
 	ClearIconName = 			"Clear Icon"
 	SelectIconName = 			"Select Icon"
+	LockWallpaperName = 		"Lock Wallpaper"
+	UnlockWallpaperName = 		"Unlock Wallpaper"
+	LockListWallpaperName = 	"Lock List Wallpaper"
+	UnlockListWallpaperName = 	"Unlock List Wallpaper"
+	LockIconName = 				"Lock Icon"
+	UnlockIconName = 			"Unlock Icon"
 )
 
 type DecorationOptions struct{
@@ -68,6 +74,17 @@ func (do DecorationOptions) Draw() (interface{}, int, error) {
 			Metadata: SelectListWallpaperName,
 			BackgroundFilename: wallpaperPath,
 		})
+		lockName := LockListWallpaperName
+		if utils.IsDecorationLocked(utils.GetTrueListWallpaperPath(currentPath)) {
+			lockName = UnlockListWallpaperName
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     lockName,
+			Selected: false,
+			Focused:  false,
+			Metadata: lockName,
+			BackgroundFilename: wallpaperPath,
+		})
 	} else {
 		title = title + " Decoration Options"
 		wallpaperPath := utils.GetWallpaperPath(currentPath, parentPath)
@@ -91,6 +108,18 @@ func (do DecorationOptions) Draw() (interface{}, int, error) {
 				ImageFilename: iconPath,
 				BackgroundFilename: wallpaperPath,
 			})
+			lockName := LockWallpaperName
+			if utils.IsDecorationLocked(utils.GetTrueWallpaperPath(currentPath)) {
+				lockName = UnlockWallpaperName
+			}
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text:     lockName,
+				Selected: false,
+				Focused:  false,
+				Metadata: lockName,
+				ImageFilename: iconPath,
+				BackgroundFilename: wallpaperPath,
+			})
 		}
 		if utils.CheckIconPath(parentPath, currentDirectory.Path) {
 			menuItems = append(menuItems, gaba.MenuItem{
@@ -110,6 +139,18 @@ func (do DecorationOptions) Draw() (interface{}, int, error) {
 			ImageFilename: iconPath,
 			BackgroundFilename: wallpaperPath,
 		})
+		lockName := LockIconName
+		if utils.IsDecorationLocked(utils.GetTrueIconPath(parentPath, currentDirectory.Path)) {
+			lockName = UnlockIconName
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     lockName,
+			Selected: false,
+			Focused:  false,
+			Metadata: lockName,
+			ImageFilename: iconPath,
+			BackgroundFilename: wallpaperPath,
+		})
 	}
 
 	// Set options
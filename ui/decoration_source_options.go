@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	sourceTagModeName		= "Console Tags"
+	sourceExclusionsName	= "Exclusions"
+	sourceRemoveName		= "Remove Source"
+)
+
+type DecorationSourceOptions struct {
+	SourceIndex	int
+	Source		models.DecorationSource
+}
+
+func InitDecorationSourceOptions(sourceIndex int, source models.DecorationSource) DecorationSourceOptions {
+	return DecorationSourceOptions{
+		SourceIndex:	sourceIndex,
+		Source:			source,
+	}
+}
+
+func (dso DecorationSourceOptions) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.DecorationSourceOptions
+}
+
+func (dso DecorationSourceOptions) Draw() (interface{}, int, error) {
+	tagModeOptions := []gaba.Option{}
+	selectedTagMode := 0
+	for index, tagMode := range []int{utils.SourceTagsFromDirectories, utils.SourceTagsFromFilenames, utils.SourceTagsIgnored} {
+		tagModeOptions = append(tagModeOptions, gaba.Option{DisplayName: utils.DescribeSourceTagMode(tagMode), Value: tagMode})
+		if tagMode == dso.Source.TagMode {
+			selectedTagMode = index
+		}
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item: gaba.MenuItem{Text: sourceTagModeName},
+			Options: tagModeOptions,
+			SelectedOption: selectedTagMode,
+		},
+		{
+			Item: gaba.MenuItem{Text: sourceExclusionsName},
+			Options: []gaba.Option{
+				{DisplayName: fmt.Sprintf("Keep (%d)", len(dso.Source.Exclusions)), Value: utils.ExclusionsKeep},
+				{DisplayName: "Add Pattern", Value: utils.ExclusionsAdd},
+				{DisplayName: "Clear All", Value: utils.ExclusionsClear},
+			},
+		},
+		{
+			Item: gaba.MenuItem{Text: sourceRemoveName},
+			Options: []gaba.Option{
+				{DisplayName: "No", Value: false},
+				{DisplayName: "Yes", Value: true},
+			},
+		},
+	}
+
+	footerHelpItems := []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "←→", HelpText: "Cycle"},
+		{ButtonName: "Start", HelpText: "Save"},
+	}
+
+	// Wait for results
+	result, err := gaba.OptionsList(dso.Source.Path, items, footerHelpItems)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if result.IsSome() {
+		selections := models.DecorationSourceSelections{}
+		for _, option := range result.Unwrap().Items {
+			value := option.Options[option.SelectedOption].Value
+			switch option.Item.Text {
+				case sourceTagModeName:
+					selections.TagMode = value.(int)
+				case sourceExclusionsName:
+					selections.ExclusionAction = value.(int)
+				case sourceRemoveName:
+					selections.Remove = value.(bool)
+			}
+		}
+		return selections, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+type DecorationSourcePicker struct {
+	CurrentPath	string
+}
+
+func InitDecorationSourcePicker(currentPath string) DecorationSourcePicker {
+	return DecorationSourcePicker{
+		CurrentPath:	currentPath,
+	}
+}
+
+func (dsp DecorationSourcePicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.DecorationSourcePicker
+}
+
+func (dsp DecorationSourcePicker) Draw() (interface{}, int, error) {
+	title := dsp.CurrentPath
+
+	// Add every visible sub directory to the list
+	var menuItems []gaba.MenuItem
+	files, err := utils.GetFileList(dsp.CurrentPath)
+	if err == nil {
+		for _, file := range files {
+			if !file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+				continue
+			}
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text:     file.Name(),
+				Selected: false,
+				Focused:  false,
+				Metadata: filepath.Join(dsp.CurrentPath, file.Name()),
+			})
+		}
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No Folders Here"
+	options.EnableAction = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Open"},
+		{ButtonName: "X", HelpText: "Add This Folder"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = "Add Decoration Source"
+	options.HelpText = []string{
+		"• A: Open the selected folder",
+		"• X: Add the folder named in the title as a decoration source",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && selection.Unwrap().ActionTriggered {
+		return dsp.CurrentPath, utils.ExitCodeAction, nil
+	}
+	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	AddDecorationSourceIndex = -1
+)
+
+type DecorationSources struct{}
+
+func InitDecorationSources() DecorationSources {
+	return DecorationSources{}
+}
+
+func (ds DecorationSources) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.DecorationSources
+}
+
+func (ds DecorationSources) Draw() (interface{}, int, error) {
+	title := "Decoration Sources"
+	sources := utils.GetDecorationSources(state.GetAppState().Config)
+
+	// Add items to menu
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     "Add Source",
+		Selected: false,
+		Focused:  false,
+		Metadata: AddDecorationSourceIndex,
+	})
+	for index, source := range sources {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     source.Path,
+			Selected: false,
+			Focused:  false,
+			Metadata: index,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(int), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -28,6 +28,13 @@ func (dtb DownloadThemesBrowser) Name() sum.Int[models.ScreenName] {
 	return models.ScreenNames.DownloadThemesBrowser
 }
 
+func (dtb DownloadThemesBrowser) SearchKey() string {
+	if dtb.ShowHiddenThemes {
+		return "hidden_themes"
+	}
+	return "download_themes"
+}
+
 func (dtb DownloadThemesBrowser) Draw() (interface{}, int, error) {
 	// Collect lists of themes available from the catalog, sorted into downloaded, new, and not downloaded buckets
 	title := "Downloadable Themes"
@@ -84,12 +91,20 @@ func (dtb DownloadThemesBrowser) Draw() (interface{}, int, error) {
 		}
 	}
 
-	// Present list of new, then not downloaded, then downloaded
+	// Present list of new, then not downloaded, then downloaded. A search replaces that order with the ranked matches
+	searchQuery := state.GetSearchQuery(dtb.SearchKey())
+	searchFields := func(menuItem gaba.MenuItem) []string {
+		theme := menuItem.Metadata.(models.ThemeSummary)
+		return []string{theme.ThemeName, theme.Author, theme.Description}
+	}
 	var menuItems []gaba.MenuItem
 	if !dtb.ShowHiddenThemes {
-		menuItems = append(menuItems, newThemes...)
-		menuItems = append(menuItems, notDownloadedThemes...)
-		menuItems = append(menuItems, downloadedThemes...)
+		var themeItems []gaba.MenuItem
+		themeItems = append(themeItems, newThemes...)
+		themeItems = append(themeItems, notDownloadedThemes...)
+		themeItems = append(themeItems, downloadedThemes...)
+		menuItems = append(menuItems, genSearchMenuItem(searchQuery))
+		menuItems = append(menuItems, filterMenuItemsBySearch(themeItems, searchQuery, searchFields)...)
 		menuItems = append(menuItems, gaba.MenuItem{
 			Text:     RefreshCatalogName,
 			Selected: false,
@@ -105,7 +120,10 @@ func (dtb DownloadThemesBrowser) Draw() (interface{}, int, error) {
 		})
 	} else {
 		title = "Hidden Themes"
-		menuItems = append(menuItems, hiddenThemes...)
+		if len(hiddenThemes) > 0 || searchQuery != "" {
+			menuItems = append(menuItems, genSearchMenuItem(searchQuery))
+		}
+		menuItems = append(menuItems, filterMenuItemsBySearch(hiddenThemes, searchQuery, searchFields)...)
 	}
 	
 
@@ -123,6 +141,7 @@ func (dtb DownloadThemesBrowser) Draw() (interface{}, int, error) {
 	options.HelpText = []string{
 		"If no entries are found, check internet connection",
 		"• A: View details of a theme with option to download",
+		"• Search: Filter by theme name, author, or description",
 		"• X: Move theme in/out of hidden status",
 	}
 
@@ -158,6 +177,9 @@ func (dtb DownloadThemesBrowser) Draw() (interface{}, int, error) {
 		if metadata == RefreshCatalogName || metadata == ShowHiddenThemesName {
 			return metadata.(string), ExitCodeSpecialResult, nil
 		}
+		if metadata == SearchItemName {
+			return nil, utils.ExitCodeSearch, nil
+		}
 		if !selection.Unwrap().ActionTriggered {
 			exit_code = utils.ExitCodeSelect
 		}
@@ -13,6 +13,7 @@ const (
 	ManageThemesDisplayName			= "Manage Available Themes"
 	ManageCurrentThemeDisplayName	= "Manage Current Theme"
 	DecorationsDisplayName 			= "Set Wallpapers & Icons"
+	AestheticToolsDisplayName		= "Aesthetic Tools"
 )
 
 type MainMenu struct{}
@@ -48,12 +49,28 @@ func (m MainMenu) Draw() (interface{}, int, error) {
 		Focused:  false,
 		Metadata: ManageCurrentThemeDisplayName,
 	})
+	profilesText := ProfilesDisplayName
+	if activeProfile := utils.GetActiveProfile(); activeProfile != "" {
+		profilesText = ProfilesDisplayName + ": " + activeProfile
+	}
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     profilesText,
+		Selected: false,
+		Focused:  false,
+		Metadata: ProfilesDisplayName,
+	})
 	menuItems = append(menuItems, gaba.MenuItem{
 		Text:     DecorationsDisplayName,
 		Selected: false,
 		Focused:  false,
 		Metadata: DecorationsDisplayName,
 	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     AestheticToolsDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: AestheticToolsDisplayName,
+	})
 
 	// Set options
 	options := gaba.DefaultListOptions(title, menuItems)
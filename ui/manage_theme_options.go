@@ -11,6 +11,9 @@ import (
 const (
 	DeleteDisplayName	= "Delete Theme"
 	RenameDisplayName	= "Rename Theme"
+	RecolorDisplayName	= "Create Recolored Variant"
+	EditRecipeDisplayName	= "Edit Recipe"
+	RegenerateDisplayName	= "Regenerate From Recipe"
 )
 
 type ManageThemeOptions struct{
@@ -38,6 +41,45 @@ func (mto ManageThemeOptions) Draw() (interface{}, int, error) {
 		Focused:  false,
 		Metadata: RenameDisplayName,
 	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     EditThemeDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: EditThemeDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     RecolorDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: RecolorDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     CompareDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: CompareDisplayName,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     CoverageReportDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: CoverageReportDisplayName,
+	})
+	// Composed themes can be rebuilt from their sources
+	if _, found := utils.LoadThemeRecipe(mto.Theme); found {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     RegenerateDisplayName,
+			Selected: false,
+			Focused:  false,
+			Metadata: RegenerateDisplayName,
+		})
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     EditRecipeDisplayName,
+			Selected: false,
+			Focused:  false,
+			Metadata: EditRecipeDisplayName,
+		})
+	}
 	menuItems = append(menuItems, gaba.MenuItem{
 		Text:     DeleteDisplayName,
 		Selected: false,
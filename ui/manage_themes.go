@@ -25,6 +25,10 @@ func (mt ManageThemes) Name() sum.Int[models.ScreenName] {
 	return models.ScreenNames.ManageThemes
 }
 
+func (mt ManageThemes) SearchKey() string {
+	return "manage_themes"
+}
+
 func (mt ManageThemes) Draw() (interface{}, int, error) {
 	title := "Manage Themes"
 
@@ -37,6 +41,10 @@ func (mt ManageThemes) Draw() (interface{}, int, error) {
 		keyIndex++
 	}
 	sort.Strings(themeKeys)
+	catalogDetails := make(map[string]models.ThemeSummary)
+	for _, summary := range state.GetAppState().ThemeCatalog {
+		catalogDetails[summary.ThemeName] = summary
+	}
 	var menuItems []gaba.MenuItem
 	for _, key := range themeKeys {
 		theme := currentThemes[key]
@@ -51,6 +59,17 @@ func (mt ManageThemes) Draw() (interface{}, int, error) {
 		}
 	}
 
+	// Filter by search, matching author and description from the catalog when it has been loaded
+	searchQuery := state.GetSearchQuery(mt.SearchKey())
+	menuItems = filterMenuItemsBySearch(menuItems, searchQuery, func(menuItem gaba.MenuItem) []string {
+		theme := menuItem.Metadata.(models.Theme)
+		summary := catalogDetails[theme.ThemeName]
+		return []string{theme.ThemeName, summary.Author, summary.Description}
+	})
+	if len(menuItems) > 0 || searchQuery != "" {
+		menuItems = append([]gaba.MenuItem{genSearchMenuItem(searchQuery)}, menuItems...)
+	}
+
 	// Set options
 	options := gaba.DefaultListOptions(title, menuItems)
 	options.SmallTitle = true
@@ -82,10 +101,14 @@ func (mt ManageThemes) Draw() (interface{}, int, error) {
 	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
 		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
 		exit_code := utils.ExitCodeAction
+		metadata := selection.Unwrap().SelectedItem.Metadata
+		if metadata == SearchItemName {
+			return nil, utils.ExitCodeSearch, nil
+		}
 		if !selection.Unwrap().ActionTriggered {
 			exit_code = utils.ExitCodeSelect
 		}
-		return selection.Unwrap().SelectedItem.Metadata.(models.Theme), exit_code, nil
+		return metadata.(models.Theme), exit_code, nil
 	}
 
 	return nil, utils.ExitCodeCancel, nil
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	SwitchProfileName	= "Switch To Profile"
+	SaveProfileName		= "Save Device Now"
+	DeleteProfileName	= "Delete Profile"
+)
+
+type ProfileOptions struct {
+	ProfileName	string
+}
+
+func InitProfileOptions(profileName string) ProfileOptions {
+	return ProfileOptions{
+		ProfileName:	profileName,
+	}
+}
+
+func (po ProfileOptions) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ProfileOptions
+}
+
+func (po ProfileOptions) Draw() (interface{}, int, error) {
+	title := po.ProfileName
+
+	// The active profile is already on the device, so it can be saved instead of switched to
+	var menuItems []gaba.MenuItem
+	if po.ProfileName == utils.GetActiveProfile() {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     SaveProfileName,
+			Selected: false,
+			Focused:  false,
+			Metadata: SaveProfileName,
+		})
+	} else {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     SwitchProfileName,
+			Selected: false,
+			Focused:  false,
+			Metadata: SwitchProfileName,
+		})
+	}
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     DeleteProfileName,
+		Selected: false,
+		Focused:  false,
+		Metadata: DeleteProfileName,
+	})
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	ProfilesDisplayName	= "Profiles"
+	newProfileName		= "New Profile"
+)
+
+type Profiles struct{}
+
+func InitProfiles() Profiles {
+	return Profiles{}
+}
+
+func (p Profiles) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.Profiles
+}
+
+func (p Profiles) Draw() (interface{}, int, error) {
+	title := ProfilesDisplayName
+	activeProfile := utils.GetActiveProfile()
+
+	// Add items to menu
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     newProfileName,
+		Selected: false,
+		Focused:  false,
+		Metadata: "",
+	})
+	for _, profileName := range utils.GetProfiles() {
+		text := profileName
+		if profileName == activeProfile {
+			text = text + " [active]"
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: profileName,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = ProfilesDisplayName
+	options.HelpText = []string{
+		"• A profile is a named copy of every icon and wallpaper",
+		"• New profiles start from the device as it is now",
+		"• Switching saves the device into the active profile,",
+		"  clears it, then applies the chosen profile",
+		"• Locked decorations stay the same in every profile",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/utils"
+	"strconv"
+)
+
+const (
+	recolorModeName		= "Recolor Mode"
+	recolorHueName		= "Hue Shift"
+	recolorTintName		= "Tint Color"
+	recolorStrengthName	= "Tint Strength"
+	recolorPaletteName	= "Palette"
+)
+
+type RecolorTheme struct{
+	Theme 	models.Theme
+}
+
+func InitRecolorTheme(theme models.Theme) RecolorTheme {
+	return RecolorTheme{
+		Theme:	theme,
+	}
+}
+
+func (rt RecolorTheme) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.RecolorTheme
+}
+
+func (rt RecolorTheme) Draw() (interface{}, int, error) {
+	title := "Recolor " + rt.Theme.ThemeName
+
+	// Build option rows
+	var hueOptions []gaba.Option
+	for degrees := 30; degrees < 360; degrees = degrees + 30 {
+		hueOptions = append(hueOptions, gaba.Option{DisplayName: "+" + strconv.Itoa(degrees) + "°", Value: degrees})
+	}
+	var tintOptions []gaba.Option
+	for _, tintName := range utils.RecolorTintNames {
+		tintOptions = append(tintOptions, gaba.Option{DisplayName: tintName, Value: tintName})
+	}
+	var strengthOptions []gaba.Option
+	for _, strength := range []int{25, 50, 75, 100} {
+		strengthOptions = append(strengthOptions, gaba.Option{DisplayName: strconv.Itoa(strength) + "%", Value: strength})
+	}
+	var paletteOptions []gaba.Option
+	for _, paletteName := range utils.RecolorPaletteNames {
+		paletteOptions = append(paletteOptions, gaba.Option{DisplayName: paletteName, Value: paletteName})
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item: gaba.MenuItem{Text: recolorModeName},
+			Options: []gaba.Option{
+				{DisplayName: utils.RecolorModeHueShift, Value: utils.RecolorModeHueShift},
+				{DisplayName: utils.RecolorModeTint, Value: utils.RecolorModeTint},
+				{DisplayName: utils.RecolorModePalette, Value: utils.RecolorModePalette},
+			},
+		},
+		{
+			Item: gaba.MenuItem{Text: recolorHueName},
+			Options: hueOptions,
+			SelectedOption: 3,
+		},
+		{
+			Item: gaba.MenuItem{Text: recolorTintName},
+			Options: tintOptions,
+			SelectedOption: 5,
+		},
+		{
+			Item: gaba.MenuItem{Text: recolorStrengthName},
+			Options: strengthOptions,
+			SelectedOption: 2,
+		},
+		{
+			Item: gaba.MenuItem{Text: recolorPaletteName},
+			Options: paletteOptions,
+		},
+	}
+
+	footerHelpItems := []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "←→", HelpText: "Cycle"},
+		{ButtonName: "Start", HelpText: "Create"},
+	}
+
+	// Wait for results
+	result, err := gaba.OptionsList(title, items, footerHelpItems)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results. Only the rows relevant to the chosen mode are used
+	if result.IsSome() {
+		selections := models.RecolorSelections{}
+		for _, option := range result.Unwrap().Items {
+			value := option.Options[option.SelectedOption].Value
+			switch option.Item.Text {
+				case recolorModeName:
+					selections.Mode = value.(string)
+				case recolorHueName:
+					selections.HueShift = value.(int)
+				case recolorTintName:
+					selections.TintName = value.(string)
+				case recolorStrengthName:
+					selections.TintStrength = value.(int)
+				case recolorPaletteName:
+					selections.PaletteName = value.(string)
+			}
+		}
+		return selections, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
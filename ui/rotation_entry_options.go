@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	rotationStartHourName	= "Starts At"
+	rotationRemoveName		= "Remove From Rotation"
+)
+
+type RotationEntryOptions struct {
+	EntryIndex	int
+	Entry		models.RotationEntry
+}
+
+func InitRotationEntryOptions(entryIndex int, entry models.RotationEntry) RotationEntryOptions {
+	return RotationEntryOptions{
+		EntryIndex:	entryIndex,
+		Entry:		entry,
+	}
+}
+
+func (reo RotationEntryOptions) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.RotationEntryOptions
+}
+
+func (reo RotationEntryOptions) Draw() (interface{}, int, error) {
+	var hourOptions []gaba.Option
+	for hour := 0; hour < 24; hour++ {
+		hourOptions = append(hourOptions, gaba.Option{DisplayName: fmt.Sprintf("%02d:00", hour), Value: hour})
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item: gaba.MenuItem{Text: rotationStartHourName},
+			Options: hourOptions,
+			SelectedOption: reo.Entry.StartHour,
+		},
+		{
+			Item: gaba.MenuItem{Text: rotationRemoveName},
+			Options: []gaba.Option{
+				{DisplayName: "No", Value: false},
+				{DisplayName: "Yes", Value: true},
+			},
+		},
+	}
+
+	footerHelpItems := []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "←→", HelpText: "Cycle"},
+		{ButtonName: "Start", HelpText: "Save"},
+	}
+
+	// Wait for results
+	result, err := gaba.OptionsList(utils.DescribeRotationEntry(reo.Entry), items, footerHelpItems)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if result.IsSome() {
+		selections := models.RotationEntrySelections{}
+		for _, option := range result.Unwrap().Items {
+			value := option.Options[option.SelectedOption].Value
+			switch option.Item.Text {
+				case rotationStartHourName:
+					selections.StartHour = value.(int)
+				case rotationRemoveName:
+					selections.Remove = value.(bool)
+			}
+		}
+		return selections, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"sort"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// RotationThemePicker chooses a local theme to add to the rotation
+type RotationThemePicker struct{}
+
+func InitRotationThemePicker() RotationThemePicker {
+	return RotationThemePicker{}
+}
+
+func (rtp RotationThemePicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.RotationThemePicker
+}
+
+func (rtp RotationThemePicker) Draw() (interface{}, int, error) {
+	title := "Add Theme"
+
+	currentThemes := utils.GetDownloadedThemes()
+	themeKeys := make([]string, 0, len(currentThemes))
+	for key := range currentThemes {
+		themeKeys = append(themeKeys, key)
+	}
+	sort.Strings(themeKeys)
+	var menuItems []gaba.MenuItem
+	for _, key := range themeKeys {
+		if !currentThemes[key].ContainsTheme {
+			continue
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     key,
+			Selected: false,
+			Focused:  false,
+			Metadata: key,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No themes to rotate! Save or download some!"
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Add"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
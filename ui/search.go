@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"sort"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"nextui-aesthetics/utils"
+)
+
+const SearchItemName = "Search"
+
+// genSearchMenuItem builds the list entry that opens the keyboard. It shows the active query so it can be edited or cleared
+func genSearchMenuItem(query string) gaba.MenuItem {
+	text := SearchItemName + "..."
+	if query != "" {
+		text = SearchItemName + ": " + query
+	}
+	return gaba.MenuItem{
+		Text:     text,
+		Selected: false,
+		Focused:  false,
+		Metadata: SearchItemName,
+	}
+}
+
+// filterMenuItemsBySearch keeps only items matching the query, best matches first. Ties keep their original order
+func filterMenuItemsBySearch(menuItems []gaba.MenuItem, query string, searchFields func(menuItem gaba.MenuItem) []string) []gaba.MenuItem {
+	if query == "" {
+		return menuItems
+	}
+	type rankedMenuItem struct {
+		menuItem	gaba.MenuItem
+		score		int
+	}
+	var rankedItems []rankedMenuItem
+	for _, menuItem := range menuItems {
+		if score, matched := utils.FuzzyMatch(query, searchFields(menuItem)...); matched {
+			rankedItems = append(rankedItems, rankedMenuItem{menuItem: menuItem, score: score})
+		}
+	}
+	sort.SliceStable(rankedItems, func(i, j int) bool {
+		return rankedItems[i].score > rankedItems[j].score
+	})
+	filteredItems := make([]gaba.MenuItem, 0, len(rankedItems))
+	for _, rankedItem := range rankedItems {
+		filteredItems = append(filteredItems, rankedItem.menuItem)
+	}
+	return filteredItems
+}
@@ -27,6 +27,11 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 
 	appState := state.GetAppState()
 
+	var aggregationOptions []gabagool.Option
+	for _, aggregationMode := range utils.AggregationModes {
+		aggregationOptions = append(aggregationOptions, gabagool.Option{DisplayName: utils.DescribeAggregationMode(aggregationMode), Value: aggregationMode})
+	}
+
 	items := []gabagool.ItemWithOptions{
 		{
 			Item: gabagool.MenuItem{
@@ -50,20 +55,57 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 			Item: gabagool.MenuItem{
 				Text: "Decoration Aggregation",
 			},
+			Options: aggregationOptions,
+			SelectedOption: func() int {
+				for index, aggregationMode := range utils.AggregationModes {
+					if aggregationMode == appState.Config.DecorationAggregationType {
+						return index
+					}
+				}
+				return 0
+			}(),
+		},
+		{
+			Item: gabagool.MenuItem{
+				Text: "Decoration Suitability",
+			},
 			Options: []gabagool.Option{
-				{DisplayName: "On Directory", Value: utils.AggregateByDirectory},
-				{DisplayName: "On Console", Value: utils.AggregateByConsole},
+				{DisplayName: "Best Fit First", Value: utils.SuitabilitySortFirst},
+				{DisplayName: "Best Fit Only", Value: utils.SuitabilityOnly},
+				{DisplayName: "Show All", Value: utils.SuitabilityShowAll},
 			},
 			SelectedOption: func() int {
-				switch appState.Config.LogLevel {
-				case "DIRECTORY":
+				switch appState.Config.DecorationSuitabilityMode {
+				case utils.SuitabilitySortFirst:
 					return 0
-				case "CONSOLE":
+				case utils.SuitabilityOnly:
 					return 1
+				case utils.SuitabilityShowAll:
+					return 2
 				}
 				return 0
 			}(),
 		},
+		{
+			Item: gabagool.MenuItem{
+				Text: "Decoration Index",
+			},
+			Options: []gabagool.Option{
+				{DisplayName: "Keep", Value: false},
+				{DisplayName: "Rebuild", Value: true},
+			},
+			SelectedOption: 0,
+		},
+		{
+			Item: gabagool.MenuItem{
+				Text: "Decoration Sources",
+			},
+			Options: []gabagool.Option{
+				{DisplayName: "Keep", Value: false},
+				{DisplayName: "Edit", Value: true},
+			},
+			SelectedOption: 0,
+		},
 	}
 
 	footerHelpItems := []gabagool.FooterHelpItem{
@@ -85,6 +127,7 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 
 	if result.IsSome() {
 		newSettingOptions := result.Unwrap().Items
+		editSources := false
 
 		for _, option := range newSettingOptions {
 			if option.Item.Text == "Log Level" {
@@ -93,6 +136,17 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 			} else if option.Item.Text == "Decoration Aggregation" {
 				decorationAggregationValue := option.Options[option.SelectedOption].Value.(int)
 				appState.Config.DecorationAggregationType = decorationAggregationValue
+			} else if option.Item.Text == "Decoration Suitability" {
+				decorationSuitabilityValue := option.Options[option.SelectedOption].Value.(int)
+				appState.Config.DecorationSuitabilityMode = decorationSuitabilityValue
+			} else if option.Item.Text == "Decoration Index" {
+				if option.Options[option.SelectedOption].Value.(bool) {
+					// Dropping the index forces a full rescan the next time decorations are browsed
+					utils.DeleteDecorationIndex()
+					state.ClearDecorationAggregations()
+				}
+			} else if option.Item.Text == "Decoration Sources" {
+				editSources = option.Options[option.SelectedOption].Value.(bool)
 			}
 		}
 
@@ -104,6 +158,9 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 
 		state.UpdateAppState(appState)
 
+		if editSources {
+			return result, utils.ExitCodeAction, nil
+		}
 		return result, 0, nil
 	}
 
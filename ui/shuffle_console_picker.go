@@ -0,0 +1,88 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+// ShuffleConsolePicker chooses the consoles a shuffle touches
+type ShuffleConsolePicker struct {
+	Options	ShuffleOptions
+}
+
+func InitShuffleConsolePicker(shuffleOptions ShuffleOptions) ShuffleConsolePicker {
+	return ShuffleConsolePicker{
+		Options:	shuffleOptions,
+	}
+}
+
+func (scp ShuffleConsolePicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ShuffleConsolePicker
+}
+
+func (scp ShuffleConsolePicker) Draw() (interface{}, int, error) {
+	title := "Choose Consoles"
+
+	// Console directories sharing a tag are shuffled together, so each tag is listed once
+	targets, err := utils.GetComponentTargets(false)
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+	var menuItems []gaba.MenuItem
+	listedTags := make(map[string]bool)
+	for _, target := range targets {
+		if target.ConsoleTag == "" || listedTags[target.ConsoleTag] {
+			continue
+		}
+		listedTags[target.ConsoleTag] = true
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     target.TargetName,
+			Selected: false,
+			Focused:  false,
+			Metadata: target.ConsoleTag,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No consoles found!"
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Toggle"},
+		{ButtonName: "Start", HelpText: "Shuffle"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		var consoleTags []string
+		for _, selectedItem := range selection.Unwrap().SelectedItems {
+			consoleTags = append(consoleTags, selectedItem.Metadata.(string))
+		}
+		return consoleTags, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	ShuffleDisplayName		= "Shuffle Decorations"
+	shuffleComponentsName	= "Shuffle"
+	shuffleModeName			= "Folders"
+	shuffleConsolesName		= "Consoles"
+	shuffleSeedName			= "Seed"
+)
+
+// ShuffleOptions carries the choices that need another screen or a prompt before the shuffle runs
+type ShuffleOptions struct {
+	Selections		models.ShuffleSelections
+	ChooseConsoles	bool
+	EnterSeed		bool
+	ForRotation		bool
+}
+
+// ShuffleDecorations sets up a shuffle to run now, or to add to the theme rotation when ForRotation is set
+type ShuffleDecorations struct {
+	ForRotation	bool
+}
+
+func InitShuffleDecorations() ShuffleDecorations {
+	return ShuffleDecorations{}
+}
+
+func (sd ShuffleDecorations) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ShuffleDecorations
+}
+
+func (sd ShuffleDecorations) Draw() (interface{}, int, error) {
+	// A rotation shuffle left on a new seed draws another one each time it comes up
+	newSeedName := "New"
+	startHelp := "Shuffle"
+	if sd.ForRotation {
+		newSeedName = "New Each Time"
+		startHelp = "Add"
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item: gaba.MenuItem{Text: shuffleComponentsName},
+			Options: []gaba.Option{
+				{DisplayName: "Wallpapers", Value: []string{utils.ComponentTypeWallpaper}},
+				{DisplayName: "Icons", Value: []string{utils.ComponentTypeIcon}},
+				{DisplayName: "Both", Value: []string{utils.ComponentTypeIcon, utils.ComponentTypeWallpaper}},
+			},
+		},
+		{
+			Item: gaba.MenuItem{Text: shuffleModeName},
+			Options: []gaba.Option{
+				{DisplayName: "Missing Only", Value: true},
+				{DisplayName: "Overwrite", Value: false},
+			},
+		},
+		{
+			Item: gaba.MenuItem{Text: shuffleConsolesName},
+			Options: []gaba.Option{
+				{DisplayName: "All", Value: false},
+				{DisplayName: "Choose", Value: true},
+			},
+		},
+		{
+			Item: gaba.MenuItem{Text: shuffleSeedName},
+			Options: []gaba.Option{
+				{DisplayName: newSeedName, Value: false},
+				{DisplayName: "Enter", Value: true},
+			},
+		},
+	}
+
+	footerHelpItems := []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "←→", HelpText: "Cycle"},
+		{ButtonName: "Start", HelpText: startHelp},
+	}
+
+	// Wait for results
+	result, err := gaba.OptionsList("Shuffle Console Decorations", items, footerHelpItems)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if result.IsSome() {
+		shuffleOptions := ShuffleOptions{
+			ForRotation:	sd.ForRotation,
+		}
+		for _, option := range result.Unwrap().Items {
+			value := option.Options[option.SelectedOption].Value
+			switch option.Item.Text {
+				case shuffleComponentsName:
+					shuffleOptions.Selections.ComponentTypes = value.([]string)
+				case shuffleModeName:
+					shuffleOptions.Selections.MissingOnly = value.(bool)
+				case shuffleConsolesName:
+					shuffleOptions.ChooseConsoles = value.(bool)
+				case shuffleSeedName:
+					shuffleOptions.EnterSeed = value.(bool)
+			}
+		}
+		return shuffleOptions, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
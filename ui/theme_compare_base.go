@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"sort"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	CompareDisplayName			= "Compare Theme"
+	compareDeviceDisplayName	= "Current Device"
+)
+
+type ThemeCompareBase struct {
+	Theme	models.Theme
+}
+
+func InitThemeCompareBase(theme models.Theme) ThemeCompareBase {
+	return ThemeCompareBase{
+		Theme:	theme,
+	}
+}
+
+func (tcb ThemeCompareBase) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeCompareBase
+}
+
+func (tcb ThemeCompareBase) Draw() (interface{}, int, error) {
+	title := "Compare " + tcb.Theme.ThemeName + " With"
+
+	// The device comes first, followed by every other local theme. An empty theme stands for the device
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     compareDeviceDisplayName,
+		Selected: false,
+		Focused:  false,
+		Metadata: models.Theme{},
+	})
+	currentThemes := utils.GetDownloadedThemes()
+	themeKeys := make([]string, 0, len(currentThemes))
+	for key := range currentThemes {
+		themeKeys = append(themeKeys, key)
+	}
+	sort.Strings(themeKeys)
+	for _, key := range themeKeys {
+		theme := currentThemes[key]
+		if theme.ContainsTheme && theme.ThemeName != tcb.Theme.ThemeName {
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text:     theme.ThemeName,
+				Selected: false,
+				Focused:  false,
+				Metadata: theme,
+				ImageFilename: utils.GetPreviewPath(theme.ThemeName),
+			})
+		}
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EnableImages = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Compare"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(models.Theme), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+type ThemeComparison struct {
+	Theme			models.Theme
+	BaseTheme		models.Theme
+	Comparisons		[]models.ComponentComparison
+}
+
+func InitThemeComparison(theme models.Theme, baseTheme models.Theme, comparisons []models.ComponentComparison) ThemeComparison {
+	return ThemeComparison{
+		Theme:			theme,
+		BaseTheme:		baseTheme,
+		Comparisons:	comparisons,
+	}
+}
+
+func (tc ThemeComparison) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeComparison
+}
+
+// comparisonBaseName names what a theme is compared against
+func comparisonBaseName(baseTheme models.Theme) string {
+	if utils.IsCurrentTheme(baseTheme) {
+		return compareDeviceDisplayName
+	}
+	return baseTheme.ThemeName
+}
+
+func (tc ThemeComparison) Draw() (interface{}, int, error) {
+	title := tc.Theme.ThemeName + " vs " + comparisonBaseName(tc.BaseTheme)
+
+	// One row per component with its counts by status
+	var menuItems []gaba.MenuItem
+	for _, componentComparison := range tc.Comparisons {
+		counts := utils.CountComparisons(componentComparison)
+		var summaryParts []string
+		for _, status := range utils.ComparisonStatuses {
+			if counts[status] > 0 {
+				summaryParts = append(summaryParts, fmt.Sprintf("%d %s", counts[status], strings.ToLower(status)))
+			}
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     componentComparison.ComponentName + ": " + strings.Join(summaryParts, ", "),
+			Selected: false,
+			Focused:  false,
+			Metadata: componentComparison,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "Neither side has any decorations"
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Details"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = "Theme Comparison"
+	options.HelpText = []string{
+		"• Identical: same image content in both",
+		"• Different: both have an image and they differ",
+		"• New: only this theme has an image there",
+		"• Absent: only the other side has an image there",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(models.ComponentComparison), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+type ThemeComparisonDetails struct {
+	Comparison			ThemeComparison
+	ComponentComparison	models.ComponentComparison
+}
+
+func InitThemeComparisonDetails(comparison ThemeComparison, componentComparison models.ComponentComparison) ThemeComparisonDetails {
+	return ThemeComparisonDetails{
+		Comparison:				comparison,
+		ComponentComparison:	componentComparison,
+	}
+}
+
+func (tcd ThemeComparisonDetails) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeComparisonDetails
+}
+
+func (tcd ThemeComparisonDetails) Draw() (interface{}, int, error) {
+	title := tcd.ComponentComparison.ComponentName + " vs " + comparisonBaseName(tcd.Comparison.BaseTheme)
+
+	// Changes first, then paths in order. The theme image is shown over the image it would replace
+	statusOrder := make(map[string]int)
+	for position, status := range utils.ComparisonStatuses {
+		statusOrder[status] = position
+	}
+	comparisons := append([]models.DecorationComparison{}, tcd.ComponentComparison.Comparisons...)
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		if statusOrder[comparisons[i].Status] != statusOrder[comparisons[j].Status] {
+			return statusOrder[comparisons[i].Status] < statusOrder[comparisons[j].Status]
+		}
+		return comparisons[i].TargetPath < comparisons[j].TargetPath
+	})
+	var menuItems []gaba.MenuItem
+	for _, comparison := range comparisons {
+		imagePath := comparison.ThemeImagePath
+		if imagePath == "" {
+			imagePath = comparison.BaseImagePath
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     comparison.Status + ": " + strings.TrimPrefix(comparison.TargetPath, utils.SDCardDirectory + "/"),
+			Selected: false,
+			Focused:  false,
+			Metadata: comparison,
+			ImageFilename: imagePath,
+			BackgroundFilename: comparison.BaseImagePath,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EnableImages = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Paths"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = "Comparison Details"
+	options.HelpText = []string{
+		"• The small image is from " + tcd.Comparison.Theme.ThemeName,
+		"• The background is from " + comparisonBaseName(tcd.Comparison.BaseTheme),
+		"• A: Show both file paths",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(models.DecorationComparison), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
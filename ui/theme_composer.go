@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"sort"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	ComposeDisplayName			= "Compose Theme"
+	ComposeCreateName			= "Create Theme"
+	ComposeRegenerateName		= "Regenerate Theme"
+	ComposeAddConsoleName		= "Add Console Source"
+	composeNoSourceName			= "None"
+)
+
+// ComposerSelection is one row of the composer. Rows for sources name a component or console, the rest an action
+type ComposerSelection struct {
+	ComponentName	string
+	ConsoleTag		string
+	Action			string
+}
+
+type ThemeComposer struct {
+	Recipe	models.ThemeRecipe
+}
+
+func InitThemeComposer(recipe models.ThemeRecipe) ThemeComposer {
+	return ThemeComposer{
+		Recipe:	recipe,
+	}
+}
+
+func (tc ThemeComposer) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeComposer
+}
+
+func (tc ThemeComposer) Draw() (interface{}, int, error) {
+	title := ComposeDisplayName
+	createName := ComposeCreateName
+	if tc.Recipe.ThemeName != "" {
+		title = "Compose " + tc.Recipe.ThemeName
+		createName = ComposeRegenerateName
+	}
+
+	// Component sources first, then console sources, then the actions
+	var menuItems []gaba.MenuItem
+	for _, componentName := range utils.ComposeComponentNames {
+		sourceName, exists := tc.Recipe.Components[componentName]
+		if !exists {
+			sourceName = composeNoSourceName
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     componentName + ": " + sourceName,
+			Selected: false,
+			Focused:  false,
+			Metadata: ComposerSelection{ComponentName: componentName},
+		})
+	}
+	consoleTags := make([]string, 0, len(tc.Recipe.Consoles))
+	for consoleTag := range tc.Recipe.Consoles {
+		consoleTags = append(consoleTags, consoleTag)
+	}
+	sort.Strings(consoleTags)
+	for _, consoleTag := range consoleTags {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     consoleTag + " Consoles: " + tc.Recipe.Consoles[consoleTag],
+			Selected: false,
+			Focused:  false,
+			Metadata: ComposerSelection{ConsoleTag: consoleTag},
+		})
+	}
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     ComposeAddConsoleName,
+		Selected: false,
+		Focused:  false,
+		Metadata: ComposerSelection{Action: ComposeAddConsoleName},
+	})
+	if len(tc.Recipe.Components) > 0 || len(tc.Recipe.Consoles) > 0 {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     createName,
+			Selected: false,
+			Focused:  false,
+			Metadata: ComposerSelection{Action: createName},
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(ComposerSelection), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
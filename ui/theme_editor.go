@@ -0,0 +1,121 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	EditThemeDisplayName	= "Edit Theme"
+	NewThemeDisplayName		= "Build New Theme"
+)
+
+// ThemeEditor lists a theme's slots. Folders holds the nested folder slots opened on the way down, innermost last
+type ThemeEditor struct {
+	Theme		models.Theme
+	Folders		[]models.ThemeSlot
+	FromTools	bool	// Opened for a newly built theme, so backing out returns to the tools menu
+}
+
+func InitThemeEditor(theme models.Theme, folders []models.ThemeSlot) ThemeEditor {
+	return ThemeEditor{
+		Theme:		theme,
+		Folders:	folders,
+	}
+}
+
+func (te ThemeEditor) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeEditor
+}
+
+// CurrentFolder gives the innermost opened folder slot, or an empty slot at the top level
+func (te ThemeEditor) CurrentFolder() models.ThemeSlot {
+	if len(te.Folders) == 0 {
+		return models.ThemeSlot{}
+	}
+	return te.Folders[len(te.Folders) - 1]
+}
+
+func (te ThemeEditor) Draw() (interface{}, int, error) {
+	title := "Edit " + te.Theme.ThemeName
+	if len(te.Folders) > 0 {
+		title = te.Theme.ThemeName + ": " + te.CurrentFolder().TargetName
+	}
+
+	slots, err := utils.GetThemeSlots(te.Theme, te.CurrentFolder())
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// One row per slot, marked like the coverage report, showing the theme's image when the slot is filled
+	var menuItems []gaba.MenuItem
+	for _, slot := range slots {
+		mark := coverageMissingMark
+		if slot.ImagePath != "" {
+			mark = coverageColumnMarks[slot.ComponentType]
+		}
+		menuItem := gaba.MenuItem{
+			Text:     "[" + mark + "] " + slot.TargetName + " " + slot.ComponentType,
+			Selected: false,
+			Focused:  false,
+			Metadata: slot,
+		}
+		if slot.ComponentType == utils.ComponentTypeIcon {
+			menuItem.ImageFilename = slot.ImagePath
+		} else {
+			menuItem.BackgroundFilename = slot.ImagePath
+		}
+		menuItems = append(menuItems, menuItem)
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No Folders Found"
+	options.EnableAction = true
+	options.EnableImages = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Edit Slot"},
+		{ButtonName: "X", HelpText: "Open Folder"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = "Theme Editor"
+	options.HelpText = []string{
+		"• Each row is an Icon, Wallpaper, or List Wallpaper slot",
+		"• A letter means the theme has that image, _ means it is empty",
+		"• A: Assign, preview, or clear the slot's image",
+		"• X: Show slots for the folders inside the selected target",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		if selection.Unwrap().ActionTriggered {
+			return selection.Unwrap().SelectedItem.Metadata.(models.ThemeSlot), utils.ExitCodeAction, nil
+		}
+		return selection.Unwrap().SelectedItem.Metadata.(models.ThemeSlot), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
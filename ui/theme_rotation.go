@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	ThemeRotationDisplayName	= "Theme Rotation"
+	RotationPolicyIndex			= -1
+	AddRotationThemeIndex		= -2
+	AddRotationShuffleIndex		= -3
+)
+
+type ThemeRotation struct{}
+
+func InitThemeRotation() ThemeRotation {
+	return ThemeRotation{}
+}
+
+func (tr ThemeRotation) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeRotation
+}
+
+func (tr ThemeRotation) Draw() (interface{}, int, error) {
+	title := ThemeRotationDisplayName
+	rotation := utils.LoadThemeRotation()
+
+	// Add items to menu
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     "Rotate: " + rotation.Policy,
+		Selected: false,
+		Focused:  false,
+		Metadata: RotationPolicyIndex,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     "Add Theme",
+		Selected: false,
+		Focused:  false,
+		Metadata: AddRotationThemeIndex,
+	})
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     "Add Shuffle",
+		Selected: false,
+		Focused:  false,
+		Metadata: AddRotationShuffleIndex,
+	})
+	for index, entry := range rotation.Entries {
+		text := utils.DescribeRotationEntry(entry)
+		if rotation.Policy == utils.RotationTimeOfDay {
+			text = fmt.Sprintf("%02d:00 %s", entry.StartHour, text)
+		} else if index == rotation.NextIndex % len(rotation.Entries) {
+			text = text + " [next]"
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: index,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = ThemeRotationDisplayName
+	options.HelpText = []string{
+		"• Add 'launch.sh rotate' from this pak to the NextUI auto start script",
+		"• Each time it runs, the next theme or shuffle is applied when due",
+		"• Daily: once per day, on the first boot of the day",
+		"• Every Boot: on every boot",
+		"• Time of Day: each entry starts at its own hour",
+		"• A on an entry: set its hour or remove it",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(int), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
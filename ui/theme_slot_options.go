@@ -0,0 +1,89 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	AssignSlotName	= "Assign Decoration"
+	PreviewSlotName	= "Preview"
+	ClearSlotName	= "Clear"
+)
+
+type ThemeSlotOptions struct {
+	Editor	ThemeEditor
+	Slot	models.ThemeSlot
+}
+
+func InitThemeSlotOptions(editor ThemeEditor, slot models.ThemeSlot) ThemeSlotOptions {
+	return ThemeSlotOptions{
+		Editor:	editor,
+		Slot:	slot,
+	}
+}
+
+func (tso ThemeSlotOptions) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeSlotOptions
+}
+
+func (tso ThemeSlotOptions) Draw() (interface{}, int, error) {
+	title := tso.Slot.TargetName + " " + tso.Slot.ComponentType
+
+	// Add items to menu
+	var menuItems []gaba.MenuItem
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     AssignSlotName,
+		Selected: false,
+		Focused:  false,
+		Metadata: AssignSlotName,
+	})
+	if tso.Slot.ImagePath != "" {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     PreviewSlotName,
+			Selected: false,
+			Focused:  false,
+			Metadata: PreviewSlotName,
+		})
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     ClearSlotName,
+			Selected: false,
+			Focused:  false,
+			Metadata: ClearSlotName,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(string), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
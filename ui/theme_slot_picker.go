@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const themeSlotOverwriteSuffix = "  [overwrite]"
+
+// ThemeSlotPicker chooses a slot for each decoration being added, one decoration at a time in selection order
+type ThemeSlotPicker struct {
+	Picker		AddToThemePicker
+	Theme		models.Theme
+	Assignments	[]models.ThemeSlotAssignment
+}
+
+func InitThemeSlotPicker(picker AddToThemePicker, theme models.Theme, assignments []models.ThemeSlotAssignment) ThemeSlotPicker {
+	return ThemeSlotPicker{
+		Picker:			picker,
+		Theme:			theme,
+		Assignments:	assignments,
+	}
+}
+
+func (tsp ThemeSlotPicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeSlotPicker
+}
+
+// CurrentDecoration gives the decoration waiting for a slot
+func (tsp ThemeSlotPicker) CurrentDecoration() models.Decoration {
+	return tsp.Picker.Decorations[len(tsp.Assignments)]
+}
+
+func (tsp ThemeSlotPicker) Draw() (interface{}, int, error) {
+	decoration := tsp.CurrentDecoration()
+	title := fmt.Sprintf("Slot for %s (%d/%d)", decoration.DecorationName, len(tsp.Assignments) + 1, len(tsp.Picker.Decorations))
+
+	slots, err := utils.GetThemeSlots(tsp.Theme, models.ThemeSlot{})
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Slots that already hold an image, or were given one earlier in this batch, are marked as overwritten
+	assignedSlots := make(map[string]bool)
+	for _, assignment := range tsp.Assignments {
+		assignedSlots[assignment.Slot.SlotPath] = true
+	}
+	var menuItems []gaba.MenuItem
+	for _, slot := range slots {
+		text := slot.ComponentName + ": " + slot.TargetName
+		if slot.ImagePath != "" || assignedSlots[slot.SlotPath] {
+			text = text + themeSlotOverwriteSuffix
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: slot,
+			ImageFilename: decoration.DecorationPath,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EnableImages = true
+
+	// Start on the slot the decoration most likely belongs in
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	if selectedIndex == 0 && visibleStartIndex == 0 {
+		selectedIndex = utils.SuggestThemeSlot(slots, decoration)
+		visibleStartIndex = selectedIndex
+	}
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Use Slot"},
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		return selection.Unwrap().SelectedItem.Metadata.(models.ThemeSlot), utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
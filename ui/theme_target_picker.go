@@ -0,0 +1,112 @@
+package ui
+
+import (
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+	"nextui-aesthetics/models"
+	"nextui-aesthetics/state"
+	"nextui-aesthetics/utils"
+)
+
+const (
+	themeCoveredSuffix = "  [in theme]"
+)
+
+type ThemeTargetPicker struct {
+	Theme			models.Theme
+	Components		[]models.Component
+	ClearSelected	bool
+	Options			models.ComponentOptionSelections
+}
+
+func InitThemeTargetPicker(theme models.Theme, components []models.Component, clearSelected bool, options models.ComponentOptionSelections) ThemeTargetPicker {
+	return ThemeTargetPicker{
+		Theme:			theme,
+		Components:		components,
+		ClearSelected:	clearSelected,
+		Options:		options,
+	}
+}
+
+func (ttp ThemeTargetPicker) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ThemeTargetPicker
+}
+
+func (ttp ThemeTargetPicker) Draw() (interface{}, int, error) {
+	title := "Choose Consoles"
+
+	// Saved themes mark and preselect what they cover. Saving or clearing the current theme starts with everything
+	isCurrentTheme := utils.IsCurrentTheme(ttp.Theme)
+	var coverage map[string]bool
+	if !isCurrentTheme {
+		coverage = utils.GetThemeTargetCoverage(ttp.Components)
+	}
+	targets, err := utils.GetComponentTargets(ttp.Options.OptionActive)
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+	var menuItems []gaba.MenuItem
+	for _, target := range targets {
+		text := target.TargetName
+		selected := isCurrentTheme
+		if !isCurrentTheme && utils.IsTargetCovered(coverage, target) {
+			text = text + themeCoveredSuffix
+			selected = true
+		}
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: selected,
+			Focused:  false,
+			Metadata: target,
+		})
+	}
+
+	// Set options
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "No consoles found!"
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+
+	// Set index
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	// Set footers
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Toggle"},
+		{ButtonName: "Start", HelpText: "Confirm"},
+	}
+
+	// Set Help
+	options.EnableHelp = true
+	options.HelpTitle = "Console Selection"
+	options.HelpText = []string{
+		"• A: Toggle a console or menu to include",
+		"• Start: Run the selected action on the chosen set only",
+		"• Entries marked [in theme] have images in this theme",
+	}
+
+	// Wait for results
+	selection, err := gaba.List(options)
+
+	// Handle error
+	if err != nil {
+		return nil, utils.ExitCodeError, err
+	}
+
+	// Process successful results
+	if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		selectedTargets := make(map[string]bool)
+		for _, selectedItem := range selection.Unwrap().SelectedItems {
+			selectedTargets[selectedItem.Metadata.(models.ComponentTarget).TargetName] = true
+		}
+		return selectedTargets, utils.ExitCodeSelect, nil
+	}
+
+	return nil, utils.ExitCodeCancel, nil
+}
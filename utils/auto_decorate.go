@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"math/rand"
+	"path/filepath"
+
+	"nextui-aesthetics/models"
+)
+
+const (
+	AutoDecorateLatestScreenshot	= "Latest Screenshot"
+	AutoDecorateRandomBoxArt		= "Random Box Art"
+	AutoDecorateMostCommon			= "Most Common"
+)
+
+var AutoDecoratePolicies = []string{AutoDecorateLatestScreenshot, AutoDecorateRandomBoxArt, AutoDecorateMostCommon}
+
+// GetAutoDecoratePlan picks a wallpaper for every top level rom directory from the decorations aggregated on its
+// console tag. Directories without a tag, without a usable image, or with a locked wallpaper are left out, and the
+// locked wallpapers are returned so they can be named
+func GetAutoDecoratePlan(consoleAggregation []models.ConsoleAggregation, selections models.AutoDecorateSelections) ([]models.DecorationPlanEntry, []string, error) {
+	var plan []models.DecorationPlanEntry
+	parentsList, err := getTopLevelRomsDirectories(false)
+	if err != nil {
+		return plan, nil, err
+	}
+
+	// Directories sharing a tag can be split across aggregations, so decorations are pooled by tag
+	consoleDecorations := make(map[string][]models.Decoration)
+	for _, aggregation := range consoleAggregation {
+		consoleDecorations[aggregation.ConsoleTag] = append(consoleDecorations[aggregation.ConsoleTag], aggregation.DecorationList...)
+	}
+
+	locks := loadDecorationLocks()
+	hashCache := loadFileHashCache()
+	chosenImages := make(map[string]string)
+	for _, parent := range parentsList {
+		consoleTag := FindConsoleTag(parent.Filename)
+		if consoleTag == "" {
+			continue
+		}
+		folderPath := filepath.Join(GetRomDirectory(), parent.Filename)
+		destinationPath := GetTrueWallpaperPath(folderPath)
+		replaces := DoesFileExists(destinationPath)
+		if (selections.MissingOnly && replaces) || locks.skip(destinationPath) {
+			continue
+		}
+
+		// Folders sharing a tag get the same image, apart from random picks
+		imagePath, chosen := chosenImages[consoleTag]
+		if !chosen || selections.Policy == AutoDecorateRandomBoxArt {
+			imagePath = chooseAutoDecoration(consoleDecorations[consoleTag], selections.Policy, hashCache)
+			chosenImages[consoleTag] = imagePath
+		}
+		if imagePath == "" {
+			continue
+		}
+		plan = append(plan, models.DecorationPlanEntry{
+			FolderPath:			folderPath,
+			ImagePath:			imagePath,
+			DestinationPath:	destinationPath,
+			Replaces:			replaces,
+		})
+	}
+	// Saving only adds the hashes read here, so the entries kept for theme updates survive a plan
+	hashCache.save()
+	return plan, locks.skippedDecorations(), nil
+}
+
+// chooseAutoDecoration applies a selection policy to a console's decorations, returning an empty path when none fit
+func chooseAutoDecoration(decorations []models.Decoration, policy string, hashCache *fileHashCache) string {
+	switch policy {
+		case AutoDecorateLatestScreenshot:
+			latestPath := ""
+			var latestTime int64
+			for _, decoration := range decorations {
+				if decoration.SourcePath == ScreenshotsDirectory && (latestPath == "" || decoration.ModTime > latestTime) {
+					latestPath = decoration.DecorationPath
+					latestTime = decoration.ModTime
+				}
+			}
+			return latestPath
+		case AutoDecorateRandomBoxArt:
+			var boxArtPaths []string
+			for _, decoration := range decorations {
+				if decoration.SourcePath == RomsDirectory {
+					boxArtPaths = append(boxArtPaths, decoration.DecorationPath)
+				}
+			}
+			if len(boxArtPaths) == 0 {
+				return ""
+			}
+			return boxArtPaths[rand.Intn(len(boxArtPaths))]
+		case AutoDecorateMostCommon:
+			// Copies of one image are counted together by content, and the first seen wins a tie
+			hashCounts := make(map[string]int)
+			hashPaths := make(map[string]string)
+			var hashOrder []string
+			for _, decoration := range decorations {
+				hash, err := hashCache.hash(decoration.DecorationPath)
+				if err != nil {
+					continue
+				}
+				if _, seen := hashPaths[hash]; !seen {
+					hashPaths[hash] = decoration.DecorationPath
+					hashOrder = append(hashOrder, hash)
+				}
+				hashCounts[hash]++
+			}
+			commonPath := ""
+			commonCount := 0
+			for _, hash := range hashOrder {
+				if hashCounts[hash] > commonCount {
+					commonPath = hashPaths[hash]
+					commonCount = hashCounts[hash]
+				}
+			}
+			return commonPath
+	}
+	return ""
+}
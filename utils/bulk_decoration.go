@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"nextui-aesthetics/models"
+)
+
+// ListSubfolders lists the visible folders directly inside a folder
+func ListSubfolders(folderPath string) []string {
+	var folders []string
+	files, err := GetFileList(folderPath)
+	if err != nil {
+		return folders
+	}
+	for _, file := range files {
+		if file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
+			folders = append(folders, filepath.Join(folderPath, file.Name()))
+		}
+	}
+	return folders
+}
+
+// ListConsoleFoldersWithTag lists the top level rom directories sharing a console tag
+func ListConsoleFoldersWithTag(consoleTag string) ([]string, error) {
+	var folders []string
+	parentsList, err := getTopLevelRomsDirectories(false)
+	if err != nil {
+		return folders, err
+	}
+	for _, parent := range parentsList {
+		if FindConsoleTag(parent.Filename) == consoleTag {
+			folders = append(folders, filepath.Join(GetRomDirectory(), parent.Filename))
+		}
+	}
+	return folders, nil
+}
+
+// ListConsoleFoldersMissing lists the top level rom directories without a decoration of the component type
+func ListConsoleFoldersMissing(componentType string) ([]string, error) {
+	var folders []string
+	parentsList, err := getTopLevelRomsDirectories(false)
+	if err != nil {
+		return folders, err
+	}
+	for _, parent := range parentsList {
+		folderPath := filepath.Join(GetRomDirectory(), parent.Filename)
+		if !DoesFileExists(FolderDecorationPath(folderPath, componentType)) {
+			folders = append(folders, folderPath)
+		}
+	}
+	return folders, nil
+}
+
+// FolderDecorationPath gives the device file a component type uses for a folder
+func FolderDecorationPath(folderPath string, componentType string) string {
+	switch componentType {
+		case ComponentTypeIcon:
+			return GetTrueIconPath(filepath.Dir(folderPath), folderPath)
+		case ComponentTypeWallpaper:
+			return GetTrueWallpaperPath(folderPath)
+		case ComponentTypeListWallpaper:
+			return GetTrueListWallpaperPath(folderPath)
+	}
+	return ""
+}
+
+// ApplyDecorationToFolders copies one image to the component type's decoration in every folder through the given copy
+// function. Locked decorations are left alone and returned for the report. Copying carries on past failures and
+// reports the first one
+func ApplyDecorationToFolders(sourcePath string, folderPaths []string, componentType string, copyDecoration func(sourcePath string, destinationPath string) error) (int, []string, error) {
+	applyCount := 0
+	var firstErr error
+	locks := loadDecorationLocks()
+	for _, folderPath := range folderPaths {
+		destinationPath := FolderDecorationPath(folderPath, componentType)
+		if destinationPath == "" {
+			continue
+		}
+		if locks.skip(destinationPath) {
+			continue
+		}
+		if err := copyDecoration(sourcePath, destinationPath); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		applyCount++
+	}
+	return applyCount, locks.skippedDecorations(), firstErr
+}
+
+// ApplyDecorationPlan copies each planned image through the given copy function. Copying carries on past failures and
+// reports the first one
+func ApplyDecorationPlan(plan []models.DecorationPlanEntry, copyDecoration func(sourcePath string, destinationPath string) error) (int, error) {
+	applyCount := 0
+	var firstErr error
+	for _, entry := range plan {
+		if err := copyDecoration(entry.ImagePath, entry.DestinationPath); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		applyCount++
+	}
+	return applyCount, firstErr
+}
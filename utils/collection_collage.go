@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bufio"
+	"image"
+	"image/draw"
+	"nextui-aesthetics/models"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+)
+
+const (
+	collageIconSize		= 512
+	collageTilePadding	= 8
+)
+
+// GenerateCollectionCollages builds a box art collage icon for every collection. Returns the number of icons written
+func GenerateCollectionCollages(selections models.CollageSelections) (int, error) {
+	logger := common.GetLoggerInstance()
+	generatedCount := 0
+
+	collectionDirectory := GetCollectionDirectory()
+	files, err := GetFileList(collectionDirectory)
+	if err != nil {
+		return generatedCount, err
+	}
+
+	for _, file := range files {
+		itemName := file.Name()
+		if file.IsDir() || filepath.Ext(itemName) != ".txt" {
+			continue
+		}
+		collectionPath := filepath.Join(collectionDirectory, itemName)
+
+		// Decide where the icon goes before doing any image work so skipped collections cost nothing
+		destinationPath := GetTrueIconPath(collectionDirectory, collectionPath)
+		if !IsCurrentTheme(selections.Destination) {
+			destinationPath = filepath.Join(selections.Destination.ThemePath, "CollectionIcons", GetSimpleFileName(itemName) + ".png")
+		}
+		if !selections.Overwrite && DoesFileExists(destinationPath) {
+			continue
+		}
+
+		artPaths := collectCollectionArtPaths(collectionPath, selections.GridSize * selections.GridSize)
+		if len(artPaths) == 0 {
+			continue
+		}
+		collage := buildCollage(artPaths, selections.GridSize)
+		if err := SavePNG(collage, destinationPath); err != nil {
+			logger.Error("Unable to save collection collage", zap.String("collection", itemName), zap.Error(err))
+			continue
+		}
+		generatedCount++
+	}
+
+	return generatedCount, nil
+}
+
+// collectCollectionArtPaths resolves collection entries to their .media box art, stopping once enough art is found
+func collectCollectionArtPaths(collectionPath string, limit int) []string {
+	var artPaths []string
+	collectionFile, err := os.Open(collectionPath)
+	if err != nil {
+		return artPaths
+	}
+	defer collectionFile.Close()
+
+	scanner := bufio.NewScanner(collectionFile)
+	for scanner.Scan() && len(artPaths) < limit {
+		romPath := strings.TrimSpace(scanner.Text())
+		if romPath == "" {
+			continue
+		}
+		// Collection entries are stored relative to the SD card root
+		if !strings.HasPrefix(romPath, common.SDCardRoot) {
+			romPath = filepath.Join(common.SDCardRoot, romPath)
+		}
+		artPath := filepath.Join(filepath.Dir(romPath), ".media", GetSimpleFileName(romPath) + ".png")
+		if DoesFileExists(artPath) {
+			artPaths = append(artPaths, artPath)
+		}
+	}
+	return artPaths
+}
+
+func buildCollage(artPaths []string, gridSize int) *image.NRGBA {
+	collage := image.NewNRGBA(image.Rect(0, 0, collageIconSize, collageIconSize))
+	tileSize := collageIconSize / gridSize
+
+	// With fewer images than tiles, shrink the grid so the collage never has holes in its first row
+	columns := gridSize
+	if len(artPaths) < gridSize {
+		columns = len(artPaths)
+	}
+	rows := (len(artPaths) + columns - 1) / columns
+	offsetX := (collageIconSize - columns * tileSize) / 2
+	offsetY := (collageIconSize - rows * tileSize) / 2
+
+	for index, artPath := range artPaths {
+		art, err := LoadImage(artPath)
+		if err != nil {
+			continue
+		}
+		tile := ScaleImageToFit(art, tileSize - collageTilePadding, tileSize - collageTilePadding)
+		column := index % columns
+		row := index / columns
+		origin := image.Pt(offsetX + column * tileSize + collageTilePadding / 2, offsetY + row * tileSize + collageTilePadding / 2)
+		draw.Draw(collage, tile.Bounds().Add(origin), tile, image.Point{}, draw.Over)
+	}
+	return collage
+}
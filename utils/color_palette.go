@@ -0,0 +1,278 @@
+package utils
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"nextui-aesthetics/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	nextUISettingsPath		= "/mnt/SDCARD/.userdata/shared/minuisettings.txt"
+	paletteSwatchPath		= "/tmp/aesthetics_palette.png"
+	paletteSampleWidth		= 64
+	paletteSampleHeight		= 48
+	paletteClusterCount		= 6
+	paletteIterations		= 12
+	NextUIMainColorKey		= "color1"
+	NextUIPrimaryAccentKey	= "color2"
+	NextUISecondaryAccentKey	= "color3"
+)
+
+type AccentSuggestion struct {
+	MainColor			color.NRGBA
+	PrimaryAccent		color.NRGBA
+	SecondaryAccent		color.NRGBA
+	Palette				[]color.NRGBA
+}
+
+// ExtractPalette clusters a downsampled copy of the image with k-means and returns the clusters ordered by size
+func ExtractPalette(img image.Image, clusterCount int) []color.NRGBA {
+	sample := ScaleImage(img, paletteSampleWidth, paletteSampleHeight)
+	var pixels []color.NRGBA
+	bounds := sample.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := sample.NRGBAAt(x, y)
+			// Mostly transparent pixels say nothing about what the user sees
+			if pixel.A >= 128 {
+				pixels = append(pixels, pixel)
+			}
+		}
+	}
+	if len(pixels) == 0 {
+		return nil
+	}
+	if clusterCount > len(pixels) {
+		clusterCount = len(pixels)
+	}
+
+	// Seed deterministically with farthest point selection so the same wallpaper always suggests the same colors
+	centers := []color.NRGBA{pixels[0]}
+	for len(centers) < clusterCount {
+		farthestIndex := 0
+		farthestDistance := -1.0
+		for index, pixel := range pixels {
+			nearest := math.MaxFloat64
+			for _, center := range centers {
+				nearest = math.Min(nearest, colorDistance(pixel, center))
+			}
+			if nearest > farthestDistance {
+				farthestDistance = nearest
+				farthestIndex = index
+			}
+		}
+		centers = append(centers, pixels[farthestIndex])
+	}
+
+	assignments := make([]int, len(pixels))
+	counts := make([]int, clusterCount)
+	for iteration := 0; iteration < paletteIterations; iteration++ {
+		// Assign each pixel to its nearest center
+		changed := false
+		for index, pixel := range pixels {
+			nearestIndex := 0
+			nearestDistance := math.MaxFloat64
+			for centerIndex, center := range centers {
+				distance := colorDistance(pixel, center)
+				if distance < nearestDistance {
+					nearestDistance = distance
+					nearestIndex = centerIndex
+				}
+			}
+			if assignments[index] != nearestIndex || iteration == 0 {
+				changed = true
+			}
+			assignments[index] = nearestIndex
+		}
+
+		// Move centers to the mean of their members
+		sums := make([][3]int, clusterCount)
+		counts = make([]int, clusterCount)
+		for index, pixel := range pixels {
+			cluster := assignments[index]
+			sums[cluster][0] = sums[cluster][0] + int(pixel.R)
+			sums[cluster][1] = sums[cluster][1] + int(pixel.G)
+			sums[cluster][2] = sums[cluster][2] + int(pixel.B)
+			counts[cluster]++
+		}
+		for cluster := range centers {
+			if counts[cluster] > 0 {
+				centers[cluster] = color.NRGBA{
+					R: uint8(sums[cluster][0] / counts[cluster]),
+					G: uint8(sums[cluster][1] / counts[cluster]),
+					B: uint8(sums[cluster][2] / counts[cluster]),
+					A: 255,
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	clusterOrder := make([]int, clusterCount)
+	for index := range clusterOrder {
+		clusterOrder[index] = index
+	}
+	sort.SliceStable(clusterOrder, func(i, j int) bool {
+		return counts[clusterOrder[i]] > counts[clusterOrder[j]]
+	})
+	var palette []color.NRGBA
+	for _, cluster := range clusterOrder {
+		if counts[cluster] > 0 {
+			palette = append(palette, centers[cluster])
+		}
+	}
+	return palette
+}
+
+// SuggestAccentColors maps a wallpaper palette onto the NextUI color slots:
+// the most vivid color leads, the next most vivid supports it, and the darkest dominant color backs them
+func SuggestAccentColors(wallpaperPath string) (AccentSuggestion, error) {
+	img, err := LoadImage(wallpaperPath)
+	if err != nil {
+		return AccentSuggestion{}, err
+	}
+	palette := ExtractPalette(img, paletteClusterCount)
+	if len(palette) == 0 {
+		return AccentSuggestion{}, fmt.Errorf("no visible colors found in %s", wallpaperPath)
+	}
+
+	byVividness := append([]color.NRGBA{}, palette...)
+	sort.SliceStable(byVividness, func(i, j int) bool {
+		return vividness(byVividness[i]) > vividness(byVividness[j])
+	})
+	byDarkness := append([]color.NRGBA{}, palette...)
+	sort.SliceStable(byDarkness, func(i, j int) bool {
+		return luminance(byDarkness[i]) < luminance(byDarkness[j])
+	})
+
+	suggestion := AccentSuggestion{
+		MainColor:			byVividness[0],
+		PrimaryAccent:		byVividness[0],
+		SecondaryAccent:	byDarkness[0],
+		Palette:			palette,
+	}
+	if len(byVividness) > 1 {
+		suggestion.PrimaryAccent = byVividness[1]
+	}
+	return suggestion, nil
+}
+
+// GeneratePaletteSwatch renders the wallpaper with the suggested colors along its bottom edge for confirmation
+func GeneratePaletteSwatch(wallpaperPath string, suggestion AccentSuggestion) (string, error) {
+	img, err := LoadImage(wallpaperPath)
+	if err != nil {
+		return "", err
+	}
+	swatch := ScaleImage(img, generatedPreviewWidth, generatedPreviewHeight)
+	swatchHeight := generatedPreviewHeight / 5
+
+	// Suggested slots take the left half, the full palette the right half
+	slots := []color.NRGBA{suggestion.MainColor, suggestion.PrimaryAccent, suggestion.SecondaryAccent}
+	slotWidth := generatedPreviewWidth / 2 / len(slots)
+	for index, slotColor := range slots {
+		area := image.Rect(index*slotWidth, generatedPreviewHeight-swatchHeight, (index+1)*slotWidth, generatedPreviewHeight)
+		draw.Draw(swatch, area, image.NewUniform(slotColor), image.Point{}, draw.Src)
+	}
+	if len(suggestion.Palette) > 0 {
+		paletteWidth := generatedPreviewWidth / 2 / len(suggestion.Palette)
+		for index, paletteColor := range suggestion.Palette {
+			area := image.Rect(generatedPreviewWidth/2+index*paletteWidth, generatedPreviewHeight-swatchHeight/2, generatedPreviewWidth/2+(index+1)*paletteWidth, generatedPreviewHeight)
+			draw.Draw(swatch, area, image.NewUniform(paletteColor), image.Point{}, draw.Src)
+		}
+	}
+
+	if err := SavePNG(swatch, paletteSwatchPath); err != nil {
+		return "", err
+	}
+	return paletteSwatchPath, nil
+}
+
+// ApplyAccentColors rewrites only the accent keys of the NextUI settings file, leaving every other setting as found
+func ApplyAccentColors(suggestion AccentSuggestion) error {
+	updates := map[string]string{
+		NextUIMainColorKey:			FormatNextUIColor(suggestion.MainColor),
+		NextUIPrimaryAccentKey:		FormatNextUIColor(suggestion.PrimaryAccent),
+		NextUISecondaryAccentKey:	FormatNextUIColor(suggestion.SecondaryAccent),
+	}
+
+	var lines []string
+	data, err := os.ReadFile(nextUISettingsPath)
+	if err == nil {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read NextUI settings: %w", err)
+	}
+
+	for index, line := range lines {
+		key, _, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		if value, exists := updates[key]; exists {
+			lines[index] = key + "=" + value
+			delete(updates, key)
+		}
+	}
+	for _, key := range []string{NextUIMainColorKey, NextUIPrimaryAccentKey, NextUISecondaryAccentKey} {
+		if value, exists := updates[key]; exists {
+			lines = append(lines, key + "=" + value)
+		}
+	}
+
+	return os.WriteFile(nextUISettingsPath, []byte(strings.Join(lines, "\n") + "\n"), defaultFilePerm)
+}
+
+func FormatNextUIColor(c color.NRGBA) string {
+	return fmt.Sprintf("0x%02X%02X%02X", c.R, c.G, c.B)
+}
+
+func colorDistance(a color.NRGBA, b color.NRGBA) float64 {
+	dr := float64(a.R) - float64(b.R)
+	dg := float64(a.G) - float64(b.G)
+	db := float64(a.B) - float64(b.B)
+	return dr*dr + dg*dg + db*db
+}
+
+// vividness favors saturated colors that are neither near black nor near white
+func vividness(c color.NRGBA) float64 {
+	_, saturation, lightness := rgbToHsl(c)
+	return saturation * (1 - math.Abs(2*lightness-1))
+}
+
+// FindThemeAccentSource picks the wallpaper that best represents a theme: the root wallpaper when present, otherwise the first wallpaper found
+func FindThemeAccentSource(components []models.Component) string {
+	firstWallpaper := ""
+	for _, component := range components {
+		if component.ComponentType.ComponentType != ComponentTypeWallpaper {
+			continue
+		}
+		for _, componentPath := range component.ComponentPaths {
+			rootWallpaper := filepath.Join(componentPath, "Root.png")
+			if DoesFileExists(rootWallpaper) {
+				return rootWallpaper
+			}
+			if firstWallpaper == "" {
+				files, err := GetFileList(componentPath)
+				if err != nil {
+					continue
+				}
+				for _, file := range files {
+					if filepath.Ext(file.Name()) == ".png" {
+						firstWallpaper = filepath.Join(componentPath, file.Name())
+						break
+					}
+				}
+			}
+		}
+	}
+	return firstWallpaper
+}
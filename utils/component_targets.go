@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"nextui-aesthetics/models"
+)
+
+// GetComponentTargets lists every console directory followed by the system menus, in the order the picker shows them
+func GetComponentTargets(onlyActive bool) ([]models.ComponentTarget, error) {
+	var targets []models.ComponentTarget
+	parentsList, err := getTopLevelRomsDirectories(onlyActive)
+	if err != nil {
+		return targets, err
+	}
+	for _, parent := range parentsList {
+		targets = append(targets, models.ComponentTarget{
+			TargetName:	parent.Filename,
+			ConsoleTag:	FindConsoleTag(parent.Filename),
+		})
+	}
+	for _, targetName := range []string{TargetCollections, TargetRecentlyPlayed, TargetTools, TargetMainMenu} {
+		targets = append(targets, models.ComponentTarget{TargetName: targetName})
+	}
+	return targets, nil
+}
+
+// GetThemeTargetCoverage reports which targets a saved theme has images for. Console coverage is keyed by console tag
+func GetThemeTargetCoverage(components []models.Component) map[string]bool {
+	coverage := make(map[string]bool)
+	for _, component := range components {
+		homeTarget := homeDirectoryTarget(component.ComponentType.ComponentHomeDirectory)
+		isRomDependent := checkComponentForRomsDependency(component.ComponentType.ComponentHomeDirectory)
+		for _, componentPath := range component.ComponentPaths {
+			files, err := GetFileList(componentPath)
+			if err != nil {
+				continue
+			}
+			for _, file := range files {
+				itemName := file.Name()
+				if filepath.Ext(itemName) != ".png" {
+					continue
+				}
+				if metaTarget, isMeta := themeMetaFileTargets[itemName]; isMeta && component.ComponentType.ContainsMetaFiles {
+					coverage[metaTarget] = true
+					continue
+				}
+				if isRomDependent {
+					firstPart := strings.Split(strings.TrimSuffix(itemName, ".png"), folderDelimiter)[0]
+					if consoleTag := FindConsoleTag(firstPart); consoleTag != "" {
+						coverage[consoleTag] = true
+					}
+				} else if homeTarget != "" {
+					coverage[homeTarget] = true
+				}
+			}
+		}
+	}
+	return coverage
+}
+
+func IsTargetCovered(coverage map[string]bool, target models.ComponentTarget) bool {
+	if target.ConsoleTag != "" {
+		return coverage[target.ConsoleTag]
+	}
+	return coverage[target.TargetName]
+}
+
+func isTargetSelected(options models.ComponentOptionSelections, targetName string) bool {
+	return options.SelectedTargets == nil || options.SelectedTargets[targetName]
+}
+
+// homeDirectoryTarget names the target for component homes outside the roms directory. Roms are filtered per console
+func homeDirectoryTarget(homeDirectory string) string {
+	switch homeDirectory {
+		case GetCollectionDirectory():
+			return TargetCollections
+		case ToolsDirectory:
+			return TargetTools
+	}
+	return ""
+}
+
+func isHomeDirectorySelected(options models.ComponentOptionSelections, homeDirectory string) bool {
+	homeTarget := homeDirectoryTarget(homeDirectory)
+	return homeTarget == "" || isTargetSelected(options, homeTarget)
+}
+
+func saveMetaDecorationSafely(sourcePath string, destinationPath string, options models.ComponentOptionSelections, update *decorationUpdate) int {
+	defer update.finishFile()
+	if !isTargetSelected(options, metaPathTargets[sourcePath]) {
+		return 0
+	}
+	return saveThemeDecorationSafely(sourcePath, destinationPath, options.OptionConfirm, update)
+}
+
+func resetMetaDecorationSafely(sourcePath string, options models.ComponentOptionSelections, update *decorationUpdate) int {
+	defer update.finishFile()
+	if !isTargetSelected(options, metaPathTargets[sourcePath]) {
+		return 0
+	}
+	return resetThemeDecorationSafely(sourcePath, options.OptionConfirm, update)
+}
+
+func applyMetaDecorationSafely(sourcePath string, destinationPath string, options models.ComponentOptionSelections, update *decorationUpdate) int {
+	if !isTargetSelected(options, metaPathTargets[destinationPath]) {
+		return 0
+	}
+	return applyThemeDecorationSafely(sourcePath, destinationPath, options.OptionPreserve, options.OptionConfirm, update)
+}
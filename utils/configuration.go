@@ -32,6 +32,14 @@ func SaveConfig(config *models.Config) error {
 	// viper.Set("show_art", config.ShowArt)
 	viper.Set("log_level", config.LogLevel)
 	viper.Set("decoration_aggregation_type", config.DecorationAggregationType)
+	viper.Set("decoration_suitability_mode", config.DecorationSuitabilityMode)
+	if config.DecorationSources != nil {
+		// Left unset until the user edits sources, so the defaults keep applying
+		viper.Set("decoration_sources", config.DecorationSources)
+	}
+	if config.TraversalRules != nil {
+		viper.Set("traversal_rules", config.TraversalRules)
+	}
 	// viper.Set("play_history_show_collections", config.PlayHistoryShowCollections)
 	// viper.Set("play_history_show_archives", config.PlayHistoryShowArchives)
 
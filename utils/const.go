@@ -12,10 +12,15 @@ const (
 	ExitCodeSelect             = 0
 	ExitCodeCancel             = 2
 	ExitCodeError              = -1
+	ExitCodeSearch             = 6
 	RecentlyPlayedDirectory    = "/mnt/SDCARD/Recently Played"
 	ToolsDirectory             = "/mnt/SDCARD/Tools/tg5040"
 	AggregateByConsole         = 1
 	AggregateByDirectory       = 0
+	AggregateBySource          = 2
+	AggregateByTheme           = 3
+	AggregateByImageClass      = 4
+	AggregateByRecency         = 5
 	CollectionsDisplayName     = "Collections"
 	CollectionsTag             = "Collections"
 	RecentlyPlayedName         = "Recently Played"
@@ -25,7 +30,34 @@ const (
 	ComponentTypeIcon          = "Icon"
 	ComponentTypeWallpaper     = "Wallpaper"
 	ComponentTypeListWallpaper = "ListWallpaper"
+	AestheticsDirectory		   = "/mnt/SDCARD/.userdata/shared/Aesthetics"
 	ThemesDirectory			   = "/mnt/SDCARD/.userdata/shared/Aesthetics/Themes"
+	ScreenshotsDirectory	   = "/mnt/SDCARD/Screenshots"
+	SDCardDirectory			   = "/mnt/SDCARD"
+	RomsDirectory			   = "/mnt/SDCARD/Roms"
+	ImageClassIcon             = "Icon"
+	ImageClassWallpaper        = "Wallpaper"
+	ImageClassListWallpaper    = "ListWallpaper"
+	ImageClassUnknown          = "Unknown"
+	SuitabilitySortFirst       = 0
+	SuitabilityOnly            = 1
+	SuitabilityShowAll         = 2
+	SourceTagsFromDirectories  = 0
+	SourceTagsFromFilenames    = 1
+	SourceTagsIgnored          = 2
+	ExclusionsKeep             = 0
+	ExclusionsAdd              = 1
+	ExclusionsClear            = 2
+	TraversalScopeScan         = "scan"
+	TraversalScopeTheme        = "theme"
+	TraversalScopeComponents   = "components"
+	TraversalDescend           = "descend"
+	TraversalLeaf              = "leaf"
+	TraversalIgnore            = "ignore"
+	TargetMainMenu             = "Main Menu"
+	TargetCollections          = CollectionsDisplayName
+	TargetRecentlyPlayed       = RecentlyPlayedName
+	TargetTools                = ToolsName
 )
 
 var ComponentTypes = map[string]models.ComponentTypeDetails{
@@ -95,13 +127,70 @@ var metaPaths = map[string]bool{
 	"/mnt/SDCARD/Tools/tg5040/.media/bglist.png": true,
 }
 
-var DecorationSources = []directorySource{
-	directorySource{DirectoryPath: ThemesDirectory, FilenamesTagFree: true},
-	directorySource{DirectoryPath: "/mnt/SDCARD/Screenshots", FilenamesTagFree: false},
-	directorySource{DirectoryPath: "/mnt/SDCARD/Roms", FilenamesTagFree: false},
+// metaPathTargets names the component target each meta file belongs to
+var metaPathTargets = map[string]string{
+	"/mnt/SDCARD/bg.png": TargetMainMenu,
+	"/mnt/SDCARD/.media/Collections.png": TargetCollections,
+	"/mnt/SDCARD/Collections/.media/bg.png": TargetCollections,
+	"/mnt/SDCARD/Collections/.media/bglist.png": TargetCollections,
+	"/mnt/SDCARD/.media/Recently Played.png": TargetRecentlyPlayed,
+	"/mnt/SDCARD/Recently Played/.media/bg.png": TargetRecentlyPlayed,
+	"/mnt/SDCARD/Recently Played/.media/bglist.png": TargetRecentlyPlayed,
+	"/mnt/SDCARD/Tools/.media/tg5040.png": TargetTools,
+	"/mnt/SDCARD/Tools/tg5040/.media/bg.png": TargetTools,
+	"/mnt/SDCARD/Tools/tg5040/.media/bglist.png": TargetTools,
 }
 
-type directorySource struct {
-	DirectoryPath    string
-	FilenamesTagFree bool
+// themeMetaFileTargets names the component target for each meta file name used inside a theme
+var themeMetaFileTargets = map[string]string{
+	"Root.png": TargetMainMenu,
+	"Collections.png": TargetCollections,
+	"Recently Played.png": TargetRecentlyPlayed,
+	"Tools.png": TargetTools,
+}
+
+// themeMetaFileDestinations maps each theme meta file to its device path by component type
+var themeMetaFileDestinations = map[string]map[string]string{
+	ComponentTypeIcon: {
+		"Collections.png": "/mnt/SDCARD/.media/Collections.png",
+		"Recently Played.png": "/mnt/SDCARD/.media/Recently Played.png",
+		"Tools.png": "/mnt/SDCARD/Tools/.media/tg5040.png",
+	},
+	ComponentTypeWallpaper: {
+		"Collections.png": "/mnt/SDCARD/Collections/.media/bg.png",
+		"Recently Played.png": "/mnt/SDCARD/Recently Played/.media/bg.png",
+		"Tools.png": "/mnt/SDCARD/Tools/tg5040/.media/bg.png",
+		"Root.png": "/mnt/SDCARD/bg.png",
+	},
+	ComponentTypeListWallpaper: {
+		"Collections.png": "/mnt/SDCARD/Collections/.media/bglist.png",
+		"Recently Played.png": "/mnt/SDCARD/Recently Played/.media/bglist.png",
+		"Tools.png": "/mnt/SDCARD/Tools/tg5040/.media/bglist.png",
+	},
+}
+
+// AggregationModes lists browse groupings in the order the decoration browser cycles through them
+var AggregationModes = []int{
+	AggregateByDirectory,
+	AggregateByConsole,
+	AggregateBySource,
+	AggregateByTheme,
+	AggregateByImageClass,
+	AggregateByRecency,
+}
+
+var DefaultDecorationSources = []models.DecorationSource{
+	models.DecorationSource{Path: ThemesDirectory, TagMode: SourceTagsFromFilenames},
+	models.DecorationSource{Path: ScreenshotsDirectory, TagMode: SourceTagsFromDirectories},
+	models.DecorationSource{Path: RomsDirectory, TagMode: SourceTagsFromDirectories},
+}
+
+// DefaultTraversalRules keep walks out of port game data and individual tool paks. User rules are checked first
+var DefaultTraversalRules = []models.TraversalRule{
+	models.TraversalRule{Scope: TraversalScopeScan, Tag: "(PORTS)", PathPrefix: RomsDirectory, MinDepth: 1, MaxDepth: 1, Action: TraversalLeaf},
+	models.TraversalRule{Scope: TraversalScopeComponents, Tag: "(PORTS)", PathPrefix: RomsDirectory, MinDepth: 1, Action: TraversalLeaf},
+	models.TraversalRule{Scope: TraversalScopeComponents, PathPrefix: ToolsDirectory, MinDepth: 1, Action: TraversalLeaf},
+	// Theme walks stop at the third port level and second tool level without entering their .media folders
+	models.TraversalRule{Scope: TraversalScopeTheme, Tag: "(PORTS)", PathPrefix: RomsDirectory, MinDepth: 4, Action: TraversalIgnore},
+	models.TraversalRule{Scope: TraversalScopeTheme, PathPrefix: ToolsDirectory, MinDepth: 3, Action: TraversalIgnore},
 }
\ No newline at end of file
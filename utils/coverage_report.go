@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"nextui-aesthetics/models"
+)
+
+const (
+	CoverageGroupConsoles		= "Consoles"
+	CoverageGroupCollections	= "Collections"
+	CoverageGroupTools			= "Tools"
+	CoverageGroupMenus			= "Menus"
+)
+
+// CoverageComponentTypes lists the matrix columns in display order
+var CoverageComponentTypes = []string{ComponentTypeIcon, ComponentTypeWallpaper, ComponentTypeListWallpaper}
+
+// coverageTarget pairs a report row with what is needed to check it on the device and inside a theme
+type coverageTarget struct {
+	row			models.CoverageRow
+	path		string
+	parentPath	string
+	themeKey	string
+}
+
+// GetCoverageReport lists every console, collection, tool and system menu on the device with the component types
+// the theme has images for. The current theme is checked against the decorations actually on the device
+func GetCoverageReport(theme models.Theme) ([]models.CoverageRow, error) {
+	targets, err := collectCoverageTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	isCurrentTheme := IsCurrentTheme(theme)
+	var themeCoverage map[string]map[string]bool
+	if !isCurrentTheme {
+		themeCoverage = collectThemeCoverage(GetThemeComponents(theme))
+	}
+
+	var rows []models.CoverageRow
+	for _, target := range targets {
+		for componentType := range target.row.Coverage {
+			if isCurrentTheme {
+				target.row.Coverage[componentType] = checkDeviceCoverage(target, componentType)
+			} else {
+				target.row.Coverage[componentType] = themeCoverage[componentType][target.themeKey]
+			}
+		}
+		rows = append(rows, target.row)
+	}
+	return rows, nil
+}
+
+// CoveragePercent reports the share of applicable cells that are themed. An empty component type counts every column
+func CoveragePercent(rows []models.CoverageRow, componentType string) int {
+	applicable := 0
+	themed := 0
+	for _, row := range rows {
+		for rowComponentType, covered := range row.Coverage {
+			if componentType != "" && rowComponentType != componentType {
+				continue
+			}
+			applicable++
+			if covered {
+				themed++
+			}
+		}
+	}
+	if applicable == 0 {
+		return 0
+	}
+	return themed * 100 / applicable
+}
+
+func collectCoverageTargets() ([]coverageTarget, error) {
+	var targets []coverageTarget
+
+	// Console directories, matched to theme images by console tag
+	parentsList, err := getTopLevelRomsDirectories(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range parentsList {
+		targets = append(targets, coverageTarget{
+			row:		newCoverageRow(parent.Filename, CoverageGroupConsoles, CoverageComponentTypes...),
+			path:		filepath.Join(GetRomDirectory(), parent.Filename),
+			parentPath:	GetRomDirectory(),
+			themeKey:	FindConsoleTag(parent.Filename),
+		})
+	}
+
+	// Collections and tools, matched to theme images by name. Collection text files only take icons
+	collectionDirectory := GetCollectionDirectory()
+	collectionFiles, _ := GetFileList(collectionDirectory)
+	for _, file := range collectionFiles {
+		itemPath := filepath.Join(collectionDirectory, file.Name())
+		if strings.HasPrefix(file.Name(), ".") || (!file.IsDir() && !CheckIfCollectionTxtChild(itemPath)) {
+			continue
+		}
+		componentTypes := CoverageComponentTypes
+		if !file.IsDir() {
+			componentTypes = []string{ComponentTypeIcon}
+		}
+		targets = append(targets, coverageTarget{
+			row:		newCoverageRow(GetSimpleFileName(itemPath), CoverageGroupCollections, componentTypes...),
+			path:		itemPath,
+			parentPath:	collectionDirectory,
+			themeKey:	GetSimpleFileName(itemPath),
+		})
+	}
+	toolFiles, _ := GetFileList(ToolsDirectory)
+	for _, file := range toolFiles {
+		itemPath := filepath.Join(ToolsDirectory, file.Name())
+		if !file.IsDir() || !strings.HasSuffix(file.Name(), ".pak") {
+			continue
+		}
+		targets = append(targets, coverageTarget{
+			row:		newCoverageRow(GetSimpleFileName(itemPath), CoverageGroupTools, CoverageComponentTypes...),
+			path:		itemPath,
+			parentPath:	ToolsDirectory,
+			themeKey:	GetSimpleFileName(itemPath),
+		})
+	}
+
+	// System menus, matched to the theme meta files
+	for _, metaTarget := range []struct{ name string; path string; parentPath string }{
+		{TargetCollections, collectionDirectory, SDCardDirectory},
+		{TargetRecentlyPlayed, filepath.Join(SDCardDirectory, RecentlyPlayedName), SDCardDirectory},
+		{TargetTools, ToolsDirectory, filepath.Dir(ToolsDirectory)},
+	} {
+		targets = append(targets, coverageTarget{
+			row:		newCoverageRow(metaTarget.name, CoverageGroupMenus, CoverageComponentTypes...),
+			path:		metaTarget.path,
+			parentPath:	metaTarget.parentPath,
+			themeKey:	metaTarget.name,
+		})
+	}
+	targets = append(targets, coverageTarget{
+		row:		newCoverageRow(TargetMainMenu, CoverageGroupMenus, ComponentTypeWallpaper),
+		path:		GetRomDirectory(),
+		themeKey:	TargetMainMenu,
+	})
+	return targets, nil
+}
+
+func newCoverageRow(targetName string, targetGroup string, componentTypes ...string) models.CoverageRow {
+	row := models.CoverageRow{
+		TargetName:		targetName,
+		TargetGroup:	targetGroup,
+		Coverage:		make(map[string]bool),
+	}
+	for _, componentType := range componentTypes {
+		row.Coverage[componentType] = false
+	}
+	return row
+}
+
+func checkDeviceCoverage(target coverageTarget, componentType string) bool {
+	devicePath := target.devicePath(componentType)
+	return devicePath != "" && DoesFileExists(devicePath)
+}
+
+// devicePath gives the decoration file a component type uses for this target on the device
+func (target coverageTarget) devicePath(componentType string) string {
+	// The main menu wallpaper lives where the roms list wallpaper helpers look for it
+	if target.row.TargetName == TargetMainMenu {
+		return GetTrueListWallpaperPath(target.path)
+	}
+	switch componentType {
+		case ComponentTypeIcon:
+			return GetTrueIconPath(target.parentPath, target.path)
+		case ComponentTypeWallpaper:
+			return GetTrueWallpaperPath(target.path)
+		case ComponentTypeListWallpaper:
+			return GetTrueListWallpaperPath(target.path)
+	}
+	return ""
+}
+
+// collectThemeCoverage maps each component type to the theme keys it has top level images for
+func collectThemeCoverage(components []models.Component) map[string]map[string]bool {
+	themeCoverage := make(map[string]map[string]bool)
+	for _, component := range components {
+		componentType := component.ComponentType.ComponentType
+		if themeCoverage[componentType] == nil {
+			themeCoverage[componentType] = make(map[string]bool)
+		}
+		isRomDependent := checkComponentForRomsDependency(component.ComponentType.ComponentHomeDirectory)
+		for _, componentPath := range component.ComponentPaths {
+			files, err := GetFileList(componentPath)
+			if err != nil {
+				continue
+			}
+			for _, file := range files {
+				itemName := file.Name()
+				if file.IsDir() || filepath.Ext(itemName) != ".png" {
+					continue
+				}
+				if metaTarget, isMeta := themeMetaFileTargets[itemName]; isMeta && component.ComponentType.ContainsMetaFiles {
+					themeCoverage[componentType][metaTarget] = true
+					continue
+				}
+				filePathParts := strings.Split(strings.TrimSuffix(itemName, ".png"), folderDelimiter)
+				if len(filePathParts) > 1 {
+					continue
+				}
+				if isRomDependent {
+					if consoleTag := FindConsoleTag(filePathParts[0]); consoleTag != "" {
+						themeCoverage[componentType][consoleTag] = true
+					}
+				} else {
+					themeCoverage[componentType][filePathParts[0]] = true
+				}
+			}
+		}
+	}
+	return themeCoverage
+}
@@ -10,11 +10,11 @@ import (
 	"strings"
 	"time"
 	"strconv"
+	"sync"
 
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/filebrowser"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
-	gaba "github.com/redria7/gabagool/pkg/gabagool"
 )
 
 const (
@@ -24,22 +24,62 @@ const (
 	consoleDelimitedCountDefault = -1
 )
 
-func GenerateDecorationAggregations() (consoleAggregationList []models.ConsoleAggregation, directoryAggregationList []models.DirectoryAggregation) {
+// GenerateDecorationAggregations scans every decoration source on a bounded pool of workers. Results are merged in
+// traversal order, so the output matches a single threaded walk. A cancelled scan returns nil aggregations and leaves
+// the stored index untouched
+func GenerateDecorationAggregations(sources []models.DecorationSource, progress *DecorationScanProgress) (consoleAggregationList []models.ConsoleAggregation, directoryAggregationList []models.DirectoryAggregation) {
+	index := loadDecorationIndex()
+	progress.expectedDirectories.Store(int64(len(index.Directories)))
+	queue := &decorationScanQueue{}
+	queue.wake = sync.NewCond(&queue.mutex)
+	for sourcePosition, decorationSource := range sources {
+		if DoesFileExists(decorationSource.Path) {
+			queue.tasks = append(queue.tasks, decorationScanTask{
+				currentPath:	decorationSource.Path,
+				originalParent:	decorationSource,
+				sortKey:		[]int{sourcePosition},
+			})
+		}
+	}
+	queue.pending = len(queue.tasks)
+	progress.DirectoriesFound.Store(int64(queue.pending))
+
+	workerResults := make([][]scannedDecoration, decorationScanWorkers)
+	var workers sync.WaitGroup
+	for worker := 0; worker < decorationScanWorkers; worker++ {
+		workers.Add(1)
+		go func(worker int) {
+			defer workers.Done()
+			workerResults[worker] = runDecorationScanWorker(queue, index, progress)
+		}(worker)
+	}
+	workers.Wait()
+	if progress.IsCancelled() {
+		return nil, nil
+	}
+	index.save()
+
+	// Restore traversal order before attaching decorations to maps
+	var scannedDecorations []scannedDecoration
+	for _, results := range workerResults {
+		scannedDecorations = append(scannedDecorations, results...)
+	}
+	sort.Slice(scannedDecorations, func(i, j int) bool {
+		return compareScanSortKeys(scannedDecorations[i].sortKey, scannedDecorations[j].sortKey) < 0
+	})
 	consoleAggregation := make(map[string]map[string][]models.Decoration)
 	directoryAggregation := make(map[string][]models.Decoration)
-	for _, decorationSource := range DecorationSources {
-		if DoesFileExists(decorationSource.DirectoryPath) {
-			consoleAggregation, directoryAggregation = collectNestedDecorations(
-				consoleAggregation, 
-				directoryAggregation,
-				decorationSource.DirectoryPath,	// current path
-				decorationSource,	// original parent
-				"", // soft parent path
-				"",	// hard parent path
-				"",	// hard console
-			)
+	for _, scanned := range scannedDecorations {
+		consoleTag := scanned.consoleTag
+		consoleSubName := scanned.consoleDecoration.ConsoleName
+		directoryName := scanned.directoryDecoration.DirectoryName
+		if consoleAggregation[consoleTag] == nil {
+			consoleAggregation[consoleTag] = make(map[string][]models.Decoration)
 		}
+		consoleAggregation[consoleTag][consoleSubName] = append(consoleAggregation[consoleTag][consoleSubName], scanned.consoleDecoration)
+		directoryAggregation[directoryName] = append(directoryAggregation[directoryName], scanned.directoryDecoration)
 	}
+
 	// Sort aggregation map results into list structures for consistent display output
 	// Sort directory aggregation
 	directoryKeys := make([]string, len(directoryAggregation))
@@ -87,33 +127,66 @@ func GenerateDecorationAggregations() (consoleAggregationList []models.ConsoleAg
 	return consoleAggregationList, directoryAggregationList
 }
 
-func collectNestedDecorations(
-	consoleAggregation map[string]map[string][]models.Decoration, 
-	directoryAggregation map[string][]models.Decoration, 
-	currentPath string, 
-	originalParent directorySource, 
-	softParentPath string,
-	hardParentPath string, 
-	hardConsole string,
-) (map[string]map[string][]models.Decoration, map[string][]models.Decoration) {
-	// Collect current path files. If error, just return currently built aggregations
-	files, err := GetFileList(currentPath)
+// runDecorationScanWorker takes directories off the shared queue until the queue drains or the scan is cancelled
+func runDecorationScanWorker(queue *decorationScanQueue, index *decorationIndex, progress *DecorationScanProgress) []scannedDecoration {
+	var results []scannedDecoration
+	for {
+		queue.mutex.Lock()
+		for len(queue.tasks) == 0 && queue.pending > 0 && !progress.IsCancelled() {
+			queue.wake.Wait()
+		}
+		if queue.pending == 0 || progress.IsCancelled() {
+			queue.mutex.Unlock()
+			queue.wake.Broadcast()
+			return results
+		}
+		task := queue.tasks[len(queue.tasks) - 1]
+		queue.tasks = queue.tasks[:len(queue.tasks) - 1]
+		queue.mutex.Unlock()
+
+		childTasks, decorations := scanDecorationDirectory(task, index)
+		results = append(results, decorations...)
+		progress.DirectoriesScanned.Inc()
+		progress.DirectoriesFound.Add(int64(len(childTasks)))
+
+		queue.mutex.Lock()
+		queue.tasks = append(queue.tasks, childTasks...)
+		queue.pending += len(childTasks) - 1
+		queue.mutex.Unlock()
+		queue.wake.Broadcast()
+	}
+}
+
+// scanDecorationDirectory evaluates a single directory, returning the child directories to drill down into and the
+// decorations found directly inside it
+func scanDecorationDirectory(task decorationScanTask, index *decorationIndex) ([]decorationScanTask, []scannedDecoration) {
+	currentPath := task.currentPath
+	originalParent := task.originalParent
+	softParentPath := task.softParentPath
+	hardParentPath := task.hardParentPath
+	hardConsole := task.hardConsole
+	var childTasks []decorationScanTask
+	var decorations []scannedDecoration
+
+	// Collect current path files from the index. If error, there is nothing to add from this directory
+	files, err := index.listDirectory(currentPath)
 	if err != nil {
-		return consoleAggregation, directoryAggregation
+		return childTasks, decorations
 	}
 	
 	// Determine console tag of current directory if possible
-	portsFolder := false
-	if hardConsole == "" {
+	if hardConsole == "" && originalParent.TagMode != SourceTagsIgnored {
 		hardConsole = FindConsoleTag(currentPath)
-		portsFolder = (hardConsole == "(PORTS)" && originalParent.DirectoryPath == "/mnt/SDCARD/Roms")
 	}
 
 	// If no hard parent found yet, scan files for any valid decorations. If some are found, set the current path as the hard parent path
 	if hardParentPath == "" {
 		for _, file := range files {
-			itemName := file.Name()
+			itemName := file.Name
 			itemExt := filepath.Ext(itemName)
+			if isExcludedFromSource(originalParent, filepath.Join(currentPath, itemName)) {
+				continue
+			}
 			if itemExt == ".png" && itemName != previewStandardName && itemName != previewHiddenName{
 				hardParentPath = currentPath
 				break
@@ -121,26 +194,33 @@ func collectNestedDecorations(
 		}
 	}
 
-	if softParentPath == "" && currentPath != originalParent.DirectoryPath {
+	if softParentPath == "" && currentPath != originalParent.Path {
 		softParentPath = currentPath
 	}
 
-	// All preconditions are checked for the current directory: check each entry and drill down in any child directories
-	for _, file := range files {
-		if file.IsDir() && (!portsFolder || file.Name() == ".media") {
-			// Current file is a directory, pass current settings and drill down
-			consoleAggregation, directoryAggregation = collectNestedDecorations(
-				consoleAggregation, 
-				directoryAggregation,
-				filepath.Join(currentPath, file.Name()),	// current path
-				originalParent,	// original parent
-				softParentPath,	// soft parent path
-				hardParentPath,	// hard parent path, default ""
-				hardConsole,	// hard console, default ""
-			)
+	// All preconditions are checked for the current directory: check each entry and queue any child directories
+	for filePosition, file := range files {
+		if isExcludedFromSource(originalParent, filepath.Join(currentPath, file.Name)) {
+			// Excluded by the source settings: skip the entry and anything beneath it
+			continue
+		}
+		if file.IsDir {
+			// Current file is a directory. Unless the traversal rules say otherwise, pass current settings down to be
+			// scanned by the next free worker
+			if !shouldDescendInto(TraversalScopeScan, originalParent.Path, currentPath, file.Name) {
+				continue
+			}
+			childTasks = append(childTasks, decorationScanTask{
+				currentPath:	filepath.Join(currentPath, file.Name),
+				originalParent:	originalParent,
+				softParentPath:	softParentPath,
+				hardParentPath:	hardParentPath,	// default ""
+				hardConsole:	hardConsole,	// default ""
+				sortKey:		extendScanSortKey(task.sortKey, filePosition),
+			})
 		} else {
 			// Current file is not a directory. Evaluate.
-			itemName := file.Name()
+			itemName := file.Name
 			itemExt := filepath.Ext(itemName)
 			// Build conditions
 			isDecoration := itemExt == ".png"
@@ -158,7 +238,7 @@ func collectNestedDecorations(
 				// Finalize soft parent
 				softParent := softParentPath
 				if softParent == "" {
-					softParent = originalParent.DirectoryPath
+					softParent = originalParent.Path
 				}
 
 				// Generate formal path
@@ -166,7 +246,7 @@ func collectNestedDecorations(
 				
 				// Finalize console tag
 				consoleTag := hardConsole
-				if hardConsole == "" && originalParent.FilenamesTagFree {
+				if hardConsole == "" && originalParent.TagMode == SourceTagsFromFilenames {
 					tempTag := FindConsoleTag(itemName)
 					if tempTag != "" {
 						consoleTag = tempTag
@@ -214,18 +294,49 @@ func collectNestedDecorations(
 					DirectoryName: directoryName, 	// For finding the decoration in the DirectoryAggregation list
 				}
 
-				// Attach decorations to map
-				if consoleAggregation[consoleTag] == nil {
-					consoleAggregation[consoleTag] = make(map[string][]models.Decoration)
-				}
-				consoleAggregation[consoleTag][consoleSubName] = append(consoleAggregation[consoleTag][consoleSubName], consoleDecoration)
-				directoryAggregation[directoryName] = append(directoryAggregation[directoryName], directoryDecoration)
+				// Classify once through the index and share the result between both aggregations
+				classifiedEntry := index.classify(currentPath, filePosition)
+				consoleDecoration.Width = classifiedEntry.Width
+				consoleDecoration.Height = classifiedEntry.Height
+				consoleDecoration.HasAlpha = classifiedEntry.HasAlpha
+				consoleDecoration.ImageClass = classifiedEntry.ImageClass
+				directoryDecoration.Width = classifiedEntry.Width
+				directoryDecoration.Height = classifiedEntry.Height
+				directoryDecoration.HasAlpha = classifiedEntry.HasAlpha
+				directoryDecoration.ImageClass = classifiedEntry.ImageClass
+				consoleDecoration.SourcePath = originalParent.Path
+				consoleDecoration.ModTime = classifiedEntry.ModTime
+				directoryDecoration.SourcePath = originalParent.Path
+				directoryDecoration.ModTime = classifiedEntry.ModTime
+
+				// Keep decorations with their traversal position so the merge can restore walk order
+				decorations = append(decorations, scannedDecoration{
+					sortKey:				extendScanSortKey(task.sortKey, filePosition),
+					consoleTag:				consoleTag,
+					consoleDecoration:		consoleDecoration,
+					directoryDecoration:	directoryDecoration,
+				})
 			}
 		}
 	}
 
-	// Return updated maps
-	return consoleAggregation, directoryAggregation
+	return childTasks, decorations
+}
+
+func extendScanSortKey(parentKey []int, position int) []int {
+	sortKey := make([]int, len(parentKey), len(parentKey) + 1)
+	copy(sortKey, parentKey)
+	return append(sortKey, position)
+}
+
+// compareScanSortKeys orders keys the way a depth first walk would have visited them
+func compareScanSortKeys(first []int, second []int) int {
+	for position := 0; position < len(first) && position < len(second); position++ {
+		if first[position] != second[position] {
+			return first[position] - second[position]
+		}
+	}
+	return len(first) - len(second)
 }
 
 func checkIfFolderIcon(mediaParent string, itemName string, itemExt string) bool {
@@ -336,10 +447,8 @@ func collectComponentsByNestedDirectoryForCurrentTheme(componentList []models.Co
 	if err != nil {
 		return componentList
 	}
-	portsFolder := (FindConsoleTag(currentPath) == "(PORTS)" && componentHomeDirectory == "/mnt/SDCARD/Roms")
-	toolsFolder := (componentHomeDirectory == ToolsDirectory && currentPath != componentHomeDirectory)
 	for _, file := range files {
-		if file.IsDir() && file.Name() != ".media" && !portsFolder && !toolsFolder {
+		if file.IsDir() && file.Name() != ".media" && shouldDescendInto(TraversalScopeComponents, componentHomeDirectory, currentPath, file.Name()) {
 			componentList = collectComponentsByNestedDirectoryForCurrentTheme(componentList, componentHomeDirectory, filepath.Join(currentPath, file.Name()))
 		}
 	}
@@ -487,37 +596,36 @@ func collectComponentsByDirectoryForSavedTheme(currentPath string, componentList
 	return componentList
 }
 
-func ApplyThemeComponentUpdates(theme models.Theme, components []models.Component, options models.ComponentOptionSelections) (string, error, int) {
+func ApplyThemeComponentUpdates(theme models.Theme, components []models.Component, options models.ComponentOptionSelections) (string, error, models.ThemeUpdateReport) {
 	isCurrentTheme := IsCurrentTheme(theme)
 	modifyCount := 0
+	update := newDecorationUpdate()
+	defer update.finish()
 
 	if options.OptionClear {
 		if ConfirmAction("Begin resetting requested components?", "") {
 			// Clear requested stuff
 			if !options.OptionConfirm {
-				res, err := gaba.ProcessMessage("Resetting requested components to default", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
-					count, err := resetToDefaultRequestedComponents(components, options)
-					if err != nil {
-						return count, err
-					}
-					return count, nil
+				count, err := runThemeUpdateStep("Resetting requested components to default", update, func() (int, error) {
+					update.progress.startStep(countDeviceDecorationFiles(components))
+					return resetToDefaultRequestedComponents(components, options, update)
 				})
-				modifyCount = modifyCount + res.Result.(int)
+				modifyCount = modifyCount + count
 				if err != nil {
-					return "Reverting to Defaults", err, modifyCount
+					return "Reverting to Defaults", err, update.report(modifyCount)
 				}
 			} else {
-				count, err := resetToDefaultRequestedComponents(components, options)
+				count, err := resetToDefaultRequestedComponents(components, options, update)
 				modifyCount = modifyCount + count
 				if err != nil {
-					return "Reverting to Defaults", err, modifyCount
+					return "Reverting to Defaults", err, update.report(modifyCount)
 				}
 			}
 		}
 		
-		// Current theme clears or saves. If clear is done for current theme, then return
-		if isCurrentTheme {
-			return "", nil, modifyCount
+		// Current theme clears or saves. If clear is done for current theme or was cancelled, then return
+		if isCurrentTheme || update.isCancelled() {
+			return "", nil, update.report(modifyCount)
 		}
 	}
 
@@ -527,56 +635,50 @@ func ApplyThemeComponentUpdates(theme models.Theme, components []models.Componen
 			// Save current theme
 			themeName, err := generateThemeName()
 			if err != nil {
-				return "Saving Current Theme", err, modifyCount
+				return "Saving Current Theme", err, update.report(modifyCount)
 			}
 			if !options.OptionConfirm {
-				res, err := gaba.ProcessMessage("Saving requested components to new theme " + themeName, gaba.ProcessMessageOptions{}, func() (interface{}, error) {
-					count, err := saveCurrentTheme(components, options, themeName)
-					if err != nil {
-						return count, err
-					}
-					return count, nil
+				count, err := runThemeUpdateStep("Saving requested components to new theme " + themeName, update, func() (int, error) {
+					update.progress.startStep(countDeviceDecorationFiles(components))
+					return saveCurrentTheme(components, options, themeName, update)
 				})
-				modifyCount = modifyCount + res.Result.(int)
+				modifyCount = modifyCount + count
 				if err != nil {
-					return "Saving Current Theme", err, modifyCount
+					return "Saving Current Theme", err, update.report(modifyCount)
 				}
 			} else {
-				count, err := saveCurrentTheme(components, options, themeName)
+				count, err := saveCurrentTheme(components, options, themeName, update)
 				modifyCount = modifyCount + count
 				if err != nil {
-					return "Saving Current Theme", err, modifyCount
+					return "Saving Current Theme", err, update.report(modifyCount)
 				}
 			}
 		}
-		return "", nil, modifyCount
+		return "", nil, update.report(modifyCount)
 	}
 
 	// Apply selected theme
 	if ConfirmAction("Begin applying requested components?", "") {
 		if !options.OptionConfirm {
-			res, err := gaba.ProcessMessage("Applying requested components from theme " + theme.ThemeName, gaba.ProcessMessageOptions{}, func() (interface{}, error) {
-				count, err := applySelectedThemeComponents(theme, components, options)
-				if err != nil {
-					return count, err
-				}
-				return count, nil
+			count, err := runThemeUpdateStep("Applying requested components from theme " + theme.ThemeName, update, func() (int, error) {
+				update.progress.startStep(countThemeComponentFiles(components))
+				return applySelectedThemeComponents(theme, components, options, update)
 			})
-			modifyCount = modifyCount + res.Result.(int)
+			modifyCount = modifyCount + count
 			if err != nil {
-				return "Applying Components", err, modifyCount
+				return "Applying Components", err, update.report(modifyCount)
 			}
 		} else {
 			// When run with the confirm option, don't wrap in a gaba process
-			count, err := applySelectedThemeComponents(theme, components, options)
+			count, err := applySelectedThemeComponents(theme, components, options, update)
 			modifyCount = modifyCount + count
 			if err != nil {
-				return "Applying Components", err, modifyCount
+				return "Applying Components", err, update.report(modifyCount)
 			}
 		}
 	}
 
-	return "", nil, modifyCount
+	return "", nil, update.report(modifyCount)
 }
 
 func generateThemeName() (string, error) {
@@ -599,7 +701,7 @@ func generateThemeName() (string, error) {
 	}
 }
 
-func saveCurrentTheme(components []models.Component, options models.ComponentOptionSelections, themeName string) (int, error) {
+func saveCurrentTheme(components []models.Component, options models.ComponentOptionSelections, themeName string, update *decorationUpdate) (int, error) {
 	modifyCount := 0
 
 	// Save meta components and build component directory/type maps for recursion
@@ -608,18 +710,18 @@ func saveCurrentTheme(components []models.Component, options models.ComponentOpt
 		if component.ComponentType.ContainsMetaFiles {
 			switch component.ComponentType.ComponentType {
 				case ComponentTypeIcon:
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/.media/Collections.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Collections.png"), options.OptionConfirm)
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/.media/Recently Played.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Recently Played.png"), options.OptionConfirm)
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/Tools/.media/tg5040.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Tools.png"), options.OptionConfirm)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/.media/Collections.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Collections.png"), options, update)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/.media/Recently Played.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Recently Played.png"), options, update)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/Tools/.media/tg5040.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Tools.png"), options, update)
 				case ComponentTypeWallpaper:
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/Collections/.media/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Collections.png"), options.OptionConfirm)
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/Recently Played/.media/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Recently Played.png"), options.OptionConfirm)
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Tools.png"), options.OptionConfirm)
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Root.png"), options.OptionConfirm)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/Collections/.media/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Collections.png"), options, update)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/Recently Played/.media/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Recently Played.png"), options, update)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Tools.png"), options, update)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/bg.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Root.png"), options, update)
 				case ComponentTypeListWallpaper:
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/Collections/.media/bglist.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Collections.png"), options.OptionConfirm)
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/Recently Played/.media/bglist.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Recently Played.png"), options.OptionConfirm)
-					modifyCount = modifyCount + saveThemeDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bglist.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Tools.png"), options.OptionConfirm)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/Collections/.media/bglist.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Collections.png"), options, update)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/Recently Played/.media/bglist.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Recently Played.png"), options, update)
+					modifyCount = modifyCount + saveMetaDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bglist.png", filepath.Join(ThemesDirectory, themeName, component.ComponentName, "Tools.png"), options, update)
 			}
 		}
 		// Add component directories and types to map while looping
@@ -629,7 +731,8 @@ func saveCurrentTheme(components []models.Component, options models.ComponentOpt
 		homeDirectories[component.ComponentType.ComponentHomeDirectory][component.ComponentType.ComponentType] = true
 	}
 	
-	// Collect valid parent directories for rom dependent directories
+	// Collect valid parent directories for rom dependent directories. Console numbering counts every folder, so the
+	// target selection is only applied once a folder's number is known
 	validParents := make(map[string][]string)
 	parentsList, err := getTopLevelRomsDirectories(options.OptionActive)
 	if err != nil {
@@ -648,8 +751,11 @@ func saveCurrentTheme(components []models.Component, options models.ComponentOpt
 
 	// Save non meta components
 	for homeDirectory, homeDirectoryComponentTypes := range homeDirectories {
+		if !isHomeDirectorySelected(options, homeDirectory) {
+			continue
+		}
 		isRomDependent := checkComponentForRomsDependency(homeDirectory)
-		modifyCount = modifyCount + saveDecorations(homeDirectory, isRomDependent, validParents, false, homeDirectoryComponentTypes, themeName, homeDirectory, options.OptionConfirm)
+		modifyCount = modifyCount + saveDecorations(homeDirectory, isRomDependent, validParents, false, homeDirectoryComponentTypes, themeName, homeDirectory, options, update)
 	}
 
 	return modifyCount, nil
@@ -673,6 +779,14 @@ func genNumberedConsoleTag(consoleDirectory string, validRomParents map[string][
 	return ""
 }
 
+// genSelectedConsoleTag numbers a console folder against every folder sharing its tag, and drops unselected folders
+func genSelectedConsoleTag(consoleDirectory string, validRomParents map[string][]string, options models.ComponentOptionSelections) string {
+	if !isTargetSelected(options, consoleDirectory) {
+		return ""
+	}
+	return genNumberedConsoleTag(consoleDirectory, validRomParents)
+}
+
 func collectConsoleDelimitedNumber(delimitedName string) int {
 	consoleParts := strings.Split(delimitedName, consoleDelimiter)
 	if len(consoleParts) <= 1 {
@@ -686,7 +800,7 @@ func collectConsoleDelimitedNumber(delimitedName string) int {
 	return realNumber
 }
 
-func saveDecorations(currentPath string, isRomDependent bool, validRomParents map[string][]string, romParentValidated bool, componentTypes map[string]bool, themeName string, componentHomeDirectory string, optionConfirm bool) int {
+func saveDecorations(currentPath string, isRomDependent bool, validRomParents map[string][]string, romParentValidated bool, componentTypes map[string]bool, themeName string, componentHomeDirectory string, options models.ComponentOptionSelections, update *decorationUpdate) int {
 	modifyCount := 0
 
 	currentDirectory := filepath.Base(currentPath)
@@ -702,9 +816,16 @@ func saveDecorations(currentPath string, isRomDependent bool, validRomParents ma
 
 	for _, file := range files {
 		// For each file in path, make decision about how to proceed
+		if update.isCancelled() {
+			break
+		}
 		itemName := file.Name()
 		itemExt := filepath.Ext(itemName)
 		if isMedia {
+			if itemExt == ".png" {
+				// Every image here counts toward the progress total, whether or not it gets handled
+				update.finishFile()
+			}
 			// reset png files
 			if itemExt == ".png" && !isMetaFile(filepath.Join(currentPath, itemName)) {
 				// Build conditions
@@ -737,52 +858,50 @@ func saveDecorations(currentPath string, isRomDependent bool, validRomParents ma
 				// Copy file to theme directory with appropriate name
 				if isMediaBg && componentTypes[ComponentTypeWallpaper] && len(decorationPathList) > 0 {
 					if isRomDependent {
-						decorationPathList[0] = genNumberedConsoleTag(decorationPathList[0], validRomParents)
+						decorationPathList[0] = genSelectedConsoleTag(decorationPathList[0], validRomParents, options)
 					}
 					if decorationPathList[0] != "" {
 						destinationName := strings.Join(decorationPathList, folderDelimiter)
-						modifyCount = modifyCount + saveThemeDecorationSafely(filepath.Join(currentPath, itemName), filepath.Join(ThemesDirectory, themeName, componentNamePrefix + "Wallpapers", destinationName + ".png"), optionConfirm)
+						modifyCount = modifyCount + saveThemeDecorationSafely(filepath.Join(currentPath, itemName), filepath.Join(ThemesDirectory, themeName, componentNamePrefix + "Wallpapers", destinationName + ".png"), options.OptionConfirm, update)
 					}
 				}
 				if isMediaBgList && componentTypes[ComponentTypeListWallpaper] && len(decorationPathList) > 0 {
 					if isRomDependent {
-						decorationPathList[0] = genNumberedConsoleTag(decorationPathList[0], validRomParents)
+						decorationPathList[0] = genSelectedConsoleTag(decorationPathList[0], validRomParents, options)
 					}
 
 					if decorationPathList[0] != "" {
 						destinationName := strings.Join(decorationPathList, folderDelimiter)
-						modifyCount = modifyCount + saveThemeDecorationSafely(filepath.Join(currentPath, itemName), filepath.Join(ThemesDirectory, themeName, componentNamePrefix + "ListWallpapers", destinationName + ".png"), optionConfirm)
+						modifyCount = modifyCount + saveThemeDecorationSafely(filepath.Join(currentPath, itemName), filepath.Join(ThemesDirectory, themeName, componentNamePrefix + "ListWallpapers", destinationName + ".png"), options.OptionConfirm, update)
 					}
 				}
 				if isFolderIcon && componentTypes[ComponentTypeIcon] {
 					iconDecorationPathList := append(decorationPathList, strings.TrimSuffix(itemName, itemExt))
 					if isRomDependent {
-						iconDecorationPathList[0] = genNumberedConsoleTag(iconDecorationPathList[0], validRomParents)
+						iconDecorationPathList[0] = genSelectedConsoleTag(iconDecorationPathList[0], validRomParents, options)
 					}
 
 					if iconDecorationPathList[0] != "" {
 						destinationName := strings.Join(iconDecorationPathList, folderDelimiter)
-						modifyCount = modifyCount + saveThemeDecorationSafely(filepath.Join(currentPath, itemName), filepath.Join(ThemesDirectory, themeName, componentNamePrefix + "Icons", destinationName + ".png"), optionConfirm)
+						modifyCount = modifyCount + saveThemeDecorationSafely(filepath.Join(currentPath, itemName), filepath.Join(ThemesDirectory, themeName, componentNamePrefix + "Icons", destinationName + ".png"), options.OptionConfirm, update)
 					}
 				}
 			}
 		} else {
 			// recurse
-			portsFolder := (FindConsoleTag(currentPath) == "(PORTS)" && componentHomeDirectory == "/mnt/SDCARD/Roms" && !(filepath.Dir(currentPath) == componentHomeDirectory || filepath.Dir(filepath.Dir(currentPath)) == componentHomeDirectory))
-			toolsFolder := (componentHomeDirectory == ToolsDirectory && !(currentPath == componentHomeDirectory || filepath.Dir(currentPath) == componentHomeDirectory))
-			if file.IsDir() && !portsFolder && !toolsFolder {
+			if file.IsDir() && shouldDescendInto(TraversalScopeTheme, componentHomeDirectory, currentPath, itemName) {
 				if isRomDependent && !romParentValidated {
 					itemConsole := FindConsoleTag(itemName)
 					validParentList := validRomParents[itemConsole]
 					for _, parentDirectory := range validParentList {
-						if parentDirectory == itemName {
+						if parentDirectory == itemName && isTargetSelected(options, itemName) {
 							romParentValidated = true
 							break
 						}
 					}
 				}
 				if !isRomDependent || romParentValidated || itemName == ".media" {
-					modifyCount = modifyCount + saveDecorations(filepath.Join(currentPath, itemName), isRomDependent, validRomParents, true, componentTypes, themeName, componentHomeDirectory, optionConfirm)
+					modifyCount = modifyCount + saveDecorations(filepath.Join(currentPath, itemName), isRomDependent, validRomParents, true, componentTypes, themeName, componentHomeDirectory, options, update)
 				}
 			}
 		}
@@ -791,13 +910,23 @@ func saveDecorations(currentPath string, isRomDependent bool, validRomParents ma
 	return modifyCount
 }
 
-func saveThemeDecorationSafely(sourcePath string, destinationPath string, confirmCopy bool) int {
+func saveThemeDecorationSafely(sourcePath string, destinationPath string, confirmCopy bool, update *decorationUpdate) int {
+	if !update.startFile(sourcePath) {
+		return 0
+	}
+	if update.skipLocked(sourcePath) {
+		return 0
+	}
+	if update.skipUnchanged(sourcePath, destinationPath) {
+		return 0
+	}
 	if confirmCopy {
 		destinationPathList := strings.Split(destinationPath, string(filepath.Separator))
 		message := "Save " + filepath.Join(destinationPathList[len(destinationPathList) - 2:]...)
 		if ConfirmActionCustomBack(message, sourcePath, "Skip") {
 			err := CopyFile(sourcePath, destinationPath)
 			if err == nil {
+				update.copied(sourcePath, destinationPath)
 				return 1
 			}
 		}
@@ -805,12 +934,19 @@ func saveThemeDecorationSafely(sourcePath string, destinationPath string, confir
 	}
 	err := CopyFile(sourcePath, destinationPath)
 	if err == nil {
+		update.copied(sourcePath, destinationPath)
 		return 1
 	}
 	return 0
 }
 
-func resetThemeDecorationSafely(sourcePath string, confirmCopy bool) int {
+func resetThemeDecorationSafely(sourcePath string, confirmCopy bool, update *decorationUpdate) int {
+	if !update.startFile(sourcePath) {
+		return 0
+	}
+	if update.skipLocked(sourcePath) {
+		return 0
+	}
 	if confirmCopy {
 		message := "Clear " + sourcePath
 		if ConfirmActionCustomBack(message, sourcePath, "Skip") {
@@ -826,7 +962,7 @@ func resetThemeDecorationSafely(sourcePath string, confirmCopy bool) int {
 	return 0
 }
 
-func resetToDefaultRequestedComponents(components []models.Component, options models.ComponentOptionSelections) (int, error) {
+func resetToDefaultRequestedComponents(components []models.Component, options models.ComponentOptionSelections, update *decorationUpdate) (int, error) {
 	modifyCount := 0
 	// Reset meta components and build component directory/type maps for recursion
 	homeDirectories := make(map[string]map[string]bool)
@@ -834,18 +970,18 @@ func resetToDefaultRequestedComponents(components []models.Component, options mo
 		if component.ComponentType.ContainsMetaFiles && !options.OptionInactive {
 			switch component.ComponentType.ComponentType {
 				case ComponentTypeIcon:
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/.media/Collections.png", options.OptionConfirm)
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/.media/Recently Played.png", options.OptionConfirm)
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/Tools/.media/tg5040.png", options.OptionConfirm)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/.media/Collections.png", options, update)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/.media/Recently Played.png", options, update)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/Tools/.media/tg5040.png", options, update)
 				case ComponentTypeWallpaper:
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/Collections/.media/bg.png", options.OptionConfirm)
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/Recently Played/.media/bg.png", options.OptionConfirm)
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bg.png", options.OptionConfirm)
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/bg.png", options.OptionConfirm)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/Collections/.media/bg.png", options, update)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/Recently Played/.media/bg.png", options, update)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bg.png", options, update)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/bg.png", options, update)
 				case ComponentTypeListWallpaper:
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/Collections/.media/bglist.png", options.OptionConfirm)
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/Recently Played/.media/bglist.png", options.OptionConfirm)
-					modifyCount = modifyCount + resetThemeDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bglist.png", options.OptionConfirm)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/Collections/.media/bglist.png", options, update)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/Recently Played/.media/bglist.png", options, update)
+					modifyCount = modifyCount + resetMetaDecorationSafely("/mnt/SDCARD/Tools/tg5040/.media/bglist.png", options, update)
 			}
 		}
 		// Add component directories and types to map while looping
@@ -862,6 +998,9 @@ func resetToDefaultRequestedComponents(components []models.Component, options mo
 		return modifyCount, err
 	}
 	for _, parent := range parentsList {
+		if !isTargetSelected(options, parent.Filename) {
+			continue
+		}
 		parentConsole := FindConsoleTag(parent.Filename)
 		if options.OptionInactive {
 			if parent.DirectoryFileCount == 0 {
@@ -874,16 +1013,19 @@ func resetToDefaultRequestedComponents(components []models.Component, options mo
 
 	// Reset non meta components
 	for homeDirectory, homeDirectoryComponentTypes := range homeDirectories {
+		if !isHomeDirectorySelected(options, homeDirectory) {
+			continue
+		}
 		isRomDependent := checkComponentForRomsDependency(homeDirectory)
 		if !options.OptionInactive || isRomDependent {
-			modifyCount = modifyCount + resetDecorations(homeDirectory, isRomDependent, validParents, false, homeDirectoryComponentTypes, homeDirectory, options.OptionConfirm)
+			modifyCount = modifyCount + resetDecorations(homeDirectory, isRomDependent, validParents, false, homeDirectoryComponentTypes, homeDirectory, options.OptionConfirm, update)
 		}
 	}
 
 	return modifyCount, nil
 }
 
-func resetDecorations(currentPath string, isRomDependent bool, validRomParents map[string][]string, romParentValidated bool, componentTypes map[string]bool, componentHomeDirectory string, optionConfirm bool) int {
+func resetDecorations(currentPath string, isRomDependent bool, validRomParents map[string][]string, romParentValidated bool, componentTypes map[string]bool, componentHomeDirectory string, optionConfirm bool, update *decorationUpdate) int {
 	modifyCount := 0
 	currentDirectory := filepath.Base(currentPath)
 	isMedia := false
@@ -898,9 +1040,16 @@ func resetDecorations(currentPath string, isRomDependent bool, validRomParents m
 
 	for _, file := range files {
 		// For each file in path, make decision about how to proceed
+		if update.isCancelled() {
+			break
+		}
 		itemName := file.Name()
 		itemExt := filepath.Ext(itemName)
 		if isMedia {
+			if itemExt == ".png" {
+				// Every image here counts toward the progress total, whether or not it gets handled
+				update.finishFile()
+			}
 			// reset png files
 			if itemExt == ".png" && !isMetaFile(filepath.Join(currentPath, itemName)) {
 				// Build conditions
@@ -913,10 +1062,10 @@ func resetDecorations(currentPath string, isRomDependent bool, validRomParents m
 				}
 				// Check for matches to components then remove if found
 				if isMediaBg && componentTypes[ComponentTypeWallpaper] {
-					modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm)
+					modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm, update)
 				}
 				if isMediaBgList && componentTypes[ComponentTypeListWallpaper] {
-					modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm)
+					modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm, update)
 				}
 				if isFolderIcon && componentTypes[ComponentTypeIcon] {
 					if !romParentValidated {
@@ -925,20 +1074,18 @@ func resetDecorations(currentPath string, isRomDependent bool, validRomParents m
 						validParentList := validRomParents[itemConsole]
 						for _, parentDirectory := range validParentList {
 							if parentDirectory == itemBase {
-								modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm)
+								modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm, update)
 								break
 							}
 						}
 					} else {
-						modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm)
+						modifyCount = modifyCount + resetThemeDecorationSafely(filepath.Join(currentPath, itemName), optionConfirm, update)
 					}
 				}
 			}
 		} else {
 			// recurse
-			portsFolder := (FindConsoleTag(currentPath) == "(PORTS)" && componentHomeDirectory == "/mnt/SDCARD/Roms" && !(filepath.Dir(currentPath) == componentHomeDirectory || filepath.Dir(filepath.Dir(currentPath)) == componentHomeDirectory))
-			toolsFolder := (componentHomeDirectory == ToolsDirectory && !(currentPath == componentHomeDirectory || filepath.Dir(currentPath) == componentHomeDirectory))
-			if file.IsDir() && !portsFolder && !toolsFolder {
+			if file.IsDir() && shouldDescendInto(TraversalScopeTheme, componentHomeDirectory, currentPath, itemName) {
 				if isRomDependent && !romParentValidated {
 					itemConsole := FindConsoleTag(itemName)
 					validParentList := validRomParents[itemConsole]
@@ -950,7 +1097,7 @@ func resetDecorations(currentPath string, isRomDependent bool, validRomParents m
 					}
 				}
 				if !isRomDependent || romParentValidated || itemName == ".media" {
-					modifyCount = modifyCount + resetDecorations(filepath.Join(currentPath, itemName), isRomDependent, validRomParents, true, componentTypes, componentHomeDirectory, optionConfirm)
+					modifyCount = modifyCount + resetDecorations(filepath.Join(currentPath, itemName), isRomDependent, validRomParents, true, componentTypes, componentHomeDirectory, optionConfirm, update)
 				}
 			}
 		}
@@ -959,10 +1106,11 @@ func resetDecorations(currentPath string, isRomDependent bool, validRomParents m
 	return modifyCount
 }
 
-func applySelectedThemeComponents(theme models.Theme, components []models.Component, options models.ComponentOptionSelections) (int, error) {
+func applySelectedThemeComponents(theme models.Theme, components []models.Component, options models.ComponentOptionSelections, update *decorationUpdate) (int, error) {
 	modifyCount := 0
 	
-	// Collect valid parent directories for non-meta components
+	// Collect valid parent directories for non-meta components. Console numbering counts every folder, so the target
+	// selection is only applied to the resolved destinations
 	validParents := make(map[string][]string)
 	parentsList, err := getTopLevelRomsDirectories(options.OptionActive)
 	if err != nil {
@@ -981,141 +1129,28 @@ func applySelectedThemeComponents(theme models.Theme, components []models.Compon
 
 	// For each component, 
 	for _, component := range components {
-		isRomDependent := checkComponentForRomsDependency(component.ComponentType.ComponentHomeDirectory)
+		homeDirectorySelected := isHomeDirectorySelected(options, component.ComponentType.ComponentHomeDirectory)
 		romParentSet := make(map[string]bool)
 		for _, componentPath := range component.ComponentPaths {
 			// For each path in a component, apply all decorations in the path according to the rules
 			files, err := GetFileList(componentPath)
 			if err == nil {
 				for _, file := range files {
+					if update.isCancelled() {
+						break
+					}
 					itemName := file.Name()
 					itemExt := filepath.Ext(itemName)
 					if itemExt == ".png" {
-						metaFileCopied := false
-						if component.ComponentType.ContainsMetaFiles {
-							switch component.ComponentType.ComponentType {
-								case ComponentTypeIcon:
-									switch itemName {
-										case "Collections.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/.media/Collections.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-										case "Recently Played.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/.media/Recently Played.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-										case "Tools.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/Tools/.media/tg5040.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-									}
-								case ComponentTypeWallpaper:
-									switch itemName {
-										case "Collections.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/Collections/.media/bg.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-										case "Recently Played.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/Recently Played/.media/bg.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-										case "Tools.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/Tools/tg5040/.media/bg.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-										case "Root.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/bg.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-									}
-								case ComponentTypeListWallpaper:
-									switch itemName {
-										case "Collections.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/Collections/.media/bglist.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-										case "Recently Played.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/Recently Played/.media/bglist.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-										case "Tools.png":
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, file.Name()), "/mnt/SDCARD/Tools/tg5040/.media/bglist.png", options.OptionPreserve, options.OptionConfirm)
-											metaFileCopied = true
-									}
-							}
-						}
-						if !metaFileCopied {
+						// Progress counts theme images, however many device folders each one lands in
+						update.finishFile()
+						sourcePath := filepath.Join(componentPath, itemName)
+						if metaDestination, isMeta := themeMetaDestination(component, itemName); isMeta {
+							modifyCount = modifyCount + applyMetaDecorationSafely(sourcePath, metaDestination, options, update)
+						} else if homeDirectorySelected {
 							// File is not a meta file, move if possible
-							itemBase := strings.TrimSuffix(itemName, itemExt)
-							filePathParts := strings.Split(itemBase, folderDelimiter)
-							filePathPartsList := [][]string{}
-							tryToPlace := true
-							if isRomDependent {
-								// Rom dependent file. Replace the first piece of the name with the user's console directory. 
-								// If the item is for that console directtory and is not the first item for that console directory, then skip.
-								consoleTag := FindConsoleTag(filePathParts[0])
-								romParentSetTag := consoleTag
-								parentNumber := consoleDelimitedCountDefault
-								if consoleTag != filePathParts[0] {
-									parentNumber = collectConsoleDelimitedNumber(filePathParts[0])
-									if parentNumber != consoleDelimitedCountDefault {
-										romParentSetTag = romParentSetTag + strconv.Itoa(parentNumber)
-									}
-								}
-								parentConsoleNameList := validParents[consoleTag]
-								parentConsoleNameListLength := len(parentConsoleNameList)
-								if parentConsoleNameListLength == 0 {
-									tryToPlace = false
-								} else {
-									// Add path parts to list of targets for every valid match
-									if parentNumber == consoleDelimitedCountDefault {
-										for _, parentConsoleDirectory := range parentConsoleNameList {
-											singlefilePathParts := append([]string{}, filePathParts...)
-											singlefilePathParts[0] = parentConsoleDirectory
-											filePathPartsList = append(filePathPartsList, singlefilePathParts)
-										}
-									} else {
-										if parentConsoleNameListLength > parentNumber && parentNumber >= 0 {
-											singlefilePathParts := append([]string{}, filePathParts...)
-											singlefilePathParts[0] = parentConsoleNameList[parentNumber]
-											filePathPartsList = append(filePathPartsList, singlefilePathParts)
-										}
-									}
-									// don't place if this image name format has been placed already
-									if len(filePathParts) == 1 {
-										if romParentSet[romParentSetTag] {
-											tryToPlace = false
-										} else {
-											romParentSet[romParentSetTag] = true
-										}
-									}
-								}
-							} else {
-								filePathPartsList = append(filePathPartsList, filePathParts)
-							}
-							if tryToPlace {
-								for _, filePathPartsIndividual := range filePathPartsList {
-									// file has a valid parent. Move if the parent directory exists
-									filePathPartsIndividual = append([]string{component.ComponentType.ComponentHomeDirectory}, filePathPartsIndividual...)
-									parentConsoleDirectory := filepath.Join(filePathPartsIndividual...)
-									if component.ComponentType.ComponentHomeDirectory == GetCollectionDirectory() {
-										if !DoesFileExists(parentConsoleDirectory) {
-											parentConsoleDirectory = parentConsoleDirectory + ".txt"
-										}
-									}
-									if component.ComponentType.ComponentHomeDirectory == ToolsDirectory {
-										if !DoesFileExists(parentConsoleDirectory) {
-											parentConsoleDirectory = parentConsoleDirectory + ".pak"
-										}
-									}
-									if DoesFileExists(parentConsoleDirectory) {
-										// File exists. Collect the destination path then copy
-										destinationPath := ""
-										switch component.ComponentType.ComponentType {
-											case ComponentTypeIcon:
-												parentPath := filepath.Join(filePathPartsIndividual[:len(filePathPartsIndividual) - 1]...)
-												destinationPath = GetTrueIconPath(parentPath, parentConsoleDirectory)
-											case ComponentTypeWallpaper:
-												destinationPath = GetTrueWallpaperPath(parentConsoleDirectory)
-											case ComponentTypeListWallpaper:
-												destinationPath = GetTrueListWallpaperPath(parentConsoleDirectory)
-										}
-										if destinationPath != "" {
-											modifyCount = modifyCount + applyThemeDecorationSafely(filepath.Join(componentPath, itemName), destinationPath, options.OptionPreserve, options.OptionConfirm)
-										}
-									}
-								}
+							for _, destinationPath := range resolveThemeDecorationDestinations(component, itemName, validParents, romParentSet, options) {
+								modifyCount = modifyCount + applyThemeDecorationSafely(sourcePath, destinationPath, options.OptionPreserve, options.OptionConfirm, update)
 							}
 						}
 					}
@@ -1127,13 +1162,125 @@ func applySelectedThemeComponents(theme models.Theme, components []models.Compon
 	return modifyCount, nil
 }
 
-func applyThemeDecorationSafely(sourcePath string, destinationPath string, existencePreCheck bool, confirmCopy bool) int {
+// themeMetaDestination finds the device path for a theme meta file such as Root.png or Tools.png
+func themeMetaDestination(component models.Component, itemName string) (string, bool) {
+	if !component.ComponentType.ContainsMetaFiles {
+		return "", false
+	}
+	destinationPath, isMeta := themeMetaFileDestinations[component.ComponentType.ComponentType][itemName]
+	return destinationPath, isMeta
+}
+
+// resolveThemeDecorationDestinations lists the device paths a non-meta theme image would be copied to. Rom dependent
+// images are placed once per console name format, which romParentSet tracks across calls for the same component.
+// Numbered names index into every folder sharing a tag, and only then are unselected folders left out
+func resolveThemeDecorationDestinations(component models.Component, itemName string, validParents map[string][]string, romParentSet map[string]bool, options models.ComponentOptionSelections) []string {
+	var destinations []string
+	isRomDependent := checkComponentForRomsDependency(component.ComponentType.ComponentHomeDirectory)
+	itemExt := filepath.Ext(itemName)
+	itemBase := strings.TrimSuffix(itemName, itemExt)
+	filePathParts := strings.Split(itemBase, folderDelimiter)
+	filePathPartsList := [][]string{}
+	tryToPlace := true
+	if isRomDependent {
+		// Rom dependent file. Replace the first piece of the name with the user's console directory. 
+		// If the item is for that console directtory and is not the first item for that console directory, then skip.
+		consoleTag := FindConsoleTag(filePathParts[0])
+		romParentSetTag := consoleTag
+		parentNumber := consoleDelimitedCountDefault
+		if consoleTag != filePathParts[0] {
+			parentNumber = collectConsoleDelimitedNumber(filePathParts[0])
+			if parentNumber != consoleDelimitedCountDefault {
+				romParentSetTag = romParentSetTag + strconv.Itoa(parentNumber)
+			}
+		}
+		parentConsoleNameList := validParents[consoleTag]
+		parentConsoleNameListLength := len(parentConsoleNameList)
+		if parentConsoleNameListLength == 0 {
+			tryToPlace = false
+		} else {
+			// Add path parts to list of targets for every valid match
+			if parentNumber == consoleDelimitedCountDefault {
+				for _, parentConsoleDirectory := range parentConsoleNameList {
+					singlefilePathParts := append([]string{}, filePathParts...)
+					singlefilePathParts[0] = parentConsoleDirectory
+					filePathPartsList = append(filePathPartsList, singlefilePathParts)
+				}
+			} else {
+				if parentConsoleNameListLength > parentNumber && parentNumber >= 0 {
+					singlefilePathParts := append([]string{}, filePathParts...)
+					singlefilePathParts[0] = parentConsoleNameList[parentNumber]
+					filePathPartsList = append(filePathPartsList, singlefilePathParts)
+				}
+			}
+			// don't place if this image name format has been placed already
+			if len(filePathParts) == 1 {
+				if romParentSet[romParentSetTag] {
+					tryToPlace = false
+				} else {
+					romParentSet[romParentSetTag] = true
+				}
+			}
+		}
+	} else {
+		filePathPartsList = append(filePathPartsList, filePathParts)
+	}
+	if tryToPlace {
+		for _, filePathPartsIndividual := range filePathPartsList {
+			if isRomDependent && !isTargetSelected(options, filePathPartsIndividual[0]) {
+				continue
+			}
+			// file has a valid parent. Move if the parent directory exists
+			filePathPartsIndividual = append([]string{component.ComponentType.ComponentHomeDirectory}, filePathPartsIndividual...)
+			parentConsoleDirectory := filepath.Join(filePathPartsIndividual...)
+			if component.ComponentType.ComponentHomeDirectory == GetCollectionDirectory() {
+				if !DoesFileExists(parentConsoleDirectory) {
+					parentConsoleDirectory = parentConsoleDirectory + ".txt"
+				}
+			}
+			if component.ComponentType.ComponentHomeDirectory == ToolsDirectory {
+				if !DoesFileExists(parentConsoleDirectory) {
+					parentConsoleDirectory = parentConsoleDirectory + ".pak"
+				}
+			}
+			if DoesFileExists(parentConsoleDirectory) {
+				// File exists. Collect the destination path then copy
+				destinationPath := ""
+				switch component.ComponentType.ComponentType {
+					case ComponentTypeIcon:
+						parentPath := filepath.Join(filePathPartsIndividual[:len(filePathPartsIndividual) - 1]...)
+						destinationPath = GetTrueIconPath(parentPath, parentConsoleDirectory)
+					case ComponentTypeWallpaper:
+						destinationPath = GetTrueWallpaperPath(parentConsoleDirectory)
+					case ComponentTypeListWallpaper:
+						destinationPath = GetTrueListWallpaperPath(parentConsoleDirectory)
+				}
+				if destinationPath != "" {
+					destinations = append(destinations, destinationPath)
+				}
+			}
+		}
+	}
+	return destinations
+}
+
+func applyThemeDecorationSafely(sourcePath string, destinationPath string, existencePreCheck bool, confirmCopy bool, update *decorationUpdate) int {
+	if !update.startFile(destinationPath) {
+		return 0
+	}
+	if update.skipLocked(destinationPath) {
+		return 0
+	}
+	if update.skipUnchanged(sourcePath, destinationPath) {
+		return 0
+	}
 	if confirmCopy {
 		sourcePathList := strings.Split(sourcePath, string(filepath.Separator))
 		message := "Apply " + filepath.Join(sourcePathList[len(sourcePathList) - 2:]...)
 		if ConfirmActionCustomBack(message, sourcePath, "Skip") {
 			err := CopyFile(sourcePath, destinationPath)
 			if err == nil {
+				update.copied(sourcePath, destinationPath)
 				return 1
 			}
 		}
@@ -1147,6 +1294,7 @@ func applyThemeDecorationSafely(sourcePath string, destinationPath string, exist
 	}
 	err := CopyFile(sourcePath, destinationPath)
 	if err == nil {
+		update.copied(sourcePath, destinationPath)
 		return 1
 	}
 	return 0
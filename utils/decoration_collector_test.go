@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"nextui-aesthetics/models"
+)
+
+// TestGenerateDecorationAggregationsOrder checks the worker pool against a single threaded walk of the same tree.
+// Both must produce identical sorted aggregations, however the workers happen to interleave
+func TestGenerateDecorationAggregationsOrder(t *testing.T) {
+	testCases := []struct {
+		name	string
+		files	[]string
+		sources	[]models.DecorationSource
+	}{
+		{
+			name:	"nested media folders",
+			files:	[]string{
+				"Roms/Game Boy Advance (GBA)/.media/bg.png",
+				"Roms/Game Boy Advance (GBA)/.media/Pokemon.png",
+				"Roms/Game Boy Advance (GBA)/Pokemon/.keep",
+				"Roms/Game Boy Advance (GBA)/.media/Mario.png",
+				"Roms/Game Boy Advance (GBA)/Hacks/.media/bglist.png",
+				"Roms/Game Boy Advance (GBA)/Hacks/.media/Zelda.png",
+				"Roms/Game Boy Advance (GBA)/Hacks/Deeper/.media/Metroid.png",
+				"Roms/Super Nintendo (SFC)/.media/Chrono.png",
+			},
+			sources:	[]models.DecorationSource{
+				{Path: "Roms", TagMode: SourceTagsFromDirectories},
+			},
+		},
+		{
+			name:	"console tags shared across directories",
+			files:	[]string{
+				"Themes/Dark/GBA (GBA)/bg.png",
+				"Themes/Dark/GBA (GBA)/icon.png",
+				"Themes/Light/Game Boy Advance (GBA)/bg.png",
+				"Themes/Light/Hacks (GBA)/a.png",
+				"Themes/Light/Hacks (GBA)/b.png",
+				"Themes/Light/(misc)/loose.png",
+				"Screens/shot (GBA).png",
+				"Screens/shot (SFC).png",
+				"Screens/nested/one (GBA).png",
+				"Screens/nested/deeper/two (SFC).png",
+			},
+			sources:	[]models.DecorationSource{
+				{Path: "Themes", TagMode: SourceTagsFromDirectories},
+				{Path: "Screens", TagMode: SourceTagsFromFilenames},
+			},
+		},
+		{
+			name:	"many sibling folders",
+			files:	manySiblingFiles(),
+			sources:	[]models.DecorationSource{
+				{Path: "Many", TagMode: SourceTagsFromDirectories},
+				{Path: "Also", TagMode: SourceTagsFromFilenames},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rootPath := t.TempDir()
+			originalIndexPath := decorationIndexPath
+			decorationIndexPath = filepath.Join(rootPath, "decoration_index.json")
+			defer func() { decorationIndexPath = originalIndexPath }()
+			for _, file := range testCase.files {
+				filePath := filepath.Join(rootPath, file)
+				if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filePath, nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var sources []models.DecorationSource
+			for _, source := range testCase.sources {
+				source.Path = filepath.Join(rootPath, source.Path)
+				sources = append(sources, source)
+			}
+
+			expectedConsoles, expectedDirectories := walkDecorationAggregations(sources)
+			if len(expectedConsoles) == 0 {
+				t.Fatal("test tree produced no decorations")
+			}
+			// Repeat runs cover both a fresh index and a cached one, along with different worker interleavings
+			for run := 0; run < 10; run++ {
+				consoles, directories := GenerateDecorationAggregations(sources, &DecorationScanProgress{})
+				if !reflect.DeepEqual(consoles, expectedConsoles) {
+					t.Fatalf("run %d console aggregation differs\ngot:  %+v\nwant: %+v", run, consoles, expectedConsoles)
+				}
+				if !reflect.DeepEqual(directories, expectedDirectories) {
+					t.Fatalf("run %d directory aggregation differs\ngot:  %+v\nwant: %+v", run, directories, expectedDirectories)
+				}
+			}
+		})
+	}
+}
+
+func manySiblingFiles() []string {
+	var files []string
+	for _, folder := range []string{"Alpha (GBA)", "Beta (SFC)", "Gamma (GBA)", "Delta (PS)", "Epsilon (SFC)", "Zeta (GBA)"} {
+		for _, name := range []string{"a.png", "b.png", "sub/c.png", "sub/.media/d.png", "sub/deeper/e.png"} {
+			files = append(files, filepath.Join("Many", folder, name))
+		}
+	}
+	for _, name := range []string{"x (GBA).png", "y (PS).png", "z.png", "inner/w (SFC).png"} {
+		files = append(files, filepath.Join("Also", name))
+	}
+	return files
+}
+
+// walkDecorationAggregations is the single threaded reference. Each directory is handled in listing order, with
+// child directories walked where they appear, the way the collector walked before it had workers
+func walkDecorationAggregations(sources []models.DecorationSource) ([]models.ConsoleAggregation, []models.DirectoryAggregation) {
+	index := loadDecorationIndex()
+	consoleAggregation := make(map[string]map[string][]models.Decoration)
+	directoryAggregation := make(map[string][]models.Decoration)
+	var walk func(task decorationScanTask)
+	walk = func(task decorationScanTask) {
+		childTasks, decorations := scanDecorationDirectory(task, index)
+		for len(childTasks) > 0 || len(decorations) > 0 {
+			if len(decorations) == 0 || (len(childTasks) > 0 && compareScanSortKeys(childTasks[0].sortKey, decorations[0].sortKey) < 0) {
+				walk(childTasks[0])
+				childTasks = childTasks[1:]
+				continue
+			}
+			scanned := decorations[0]
+			decorations = decorations[1:]
+			if consoleAggregation[scanned.consoleTag] == nil {
+				consoleAggregation[scanned.consoleTag] = make(map[string][]models.Decoration)
+			}
+			consoleName := scanned.consoleDecoration.ConsoleName
+			consoleAggregation[scanned.consoleTag][consoleName] = append(consoleAggregation[scanned.consoleTag][consoleName], scanned.consoleDecoration)
+			directoryName := scanned.directoryDecoration.DirectoryName
+			directoryAggregation[directoryName] = append(directoryAggregation[directoryName], scanned.directoryDecoration)
+		}
+	}
+	for sourcePosition, source := range sources {
+		walk(decorationScanTask{currentPath: source.Path, originalParent: source, sortKey: []int{sourcePosition}})
+	}
+
+	var consoleAggregationList []models.ConsoleAggregation
+	var directoryAggregationList []models.DirectoryAggregation
+	var directoryKeys []string
+	for key := range directoryAggregation {
+		directoryKeys = append(directoryKeys, key)
+	}
+	sort.Strings(directoryKeys)
+	for _, key := range directoryKeys {
+		directoryAggregationList = append(directoryAggregationList, models.DirectoryAggregation{DirectoryName: key, DecorationList: directoryAggregation[key]})
+	}
+	var consoleKeys []string
+	for key := range consoleAggregation {
+		consoleKeys = append(consoleKeys, key)
+	}
+	sort.Strings(consoleKeys)
+	for _, key := range consoleKeys {
+		var consoleSubKeys []string
+		for subKey := range consoleAggregation[key] {
+			consoleSubKeys = append(consoleSubKeys, subKey)
+		}
+		sort.Strings(consoleSubKeys)
+		for _, subKey := range consoleSubKeys {
+			consoleAggregationList = append(consoleAggregationList, models.ConsoleAggregation{ConsoleTag: key, ConsoleName: subKey, DecorationList: consoleAggregation[key][subKey]})
+		}
+	}
+	return consoleAggregationList, directoryAggregationList
+}
@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"nextui-aesthetics/models"
+)
+
+const (
+	noThemeGroupName = "Not In A Theme"
+)
+
+var imageClassGroupOrder = []string{ImageClassIcon, ImageClassWallpaper, ImageClassListWallpaper, ImageClassUnknown}
+
+var recencyGroups = []struct {
+	name	string
+	maxAge	time.Duration
+}{
+	{name: "Today", maxAge: 24 * time.Hour},
+	{name: "This Week", maxAge: 7 * 24 * time.Hour},
+	{name: "This Month", maxAge: 30 * 24 * time.Hour},
+	{name: "This Year", maxAge: 365 * 24 * time.Hour},
+	{name: "Older", maxAge: 0},
+}
+
+func DescribeAggregationMode(mode int) string {
+	switch mode {
+		case AggregateByConsole:
+			return "On Console"
+		case AggregateBySource:
+			return "On Source"
+		case AggregateByTheme:
+			return "On Theme"
+		case AggregateByImageClass:
+			return "On Image Size"
+		case AggregateByRecency:
+			return "On Recent Changes"
+	}
+	return "On Directory"
+}
+
+// NextAggregationMode returns the mode after the given one, wrapping back to the first
+func NextAggregationMode(mode int) int {
+	for position, aggregationMode := range AggregationModes {
+		if aggregationMode == mode {
+			return AggregationModes[(position + 1) % len(AggregationModes)]
+		}
+	}
+	return AggregationModes[0]
+}
+
+// DescribeDecorationSource names the default sources, and any other source by its path on the SD card, since two
+// custom sources can share a folder name
+func DescribeDecorationSource(sourcePath string) string {
+	switch sourcePath {
+		case ThemesDirectory:
+			return "Themes"
+		case ScreenshotsDirectory:
+			return "Screenshots"
+		case RomsDirectory:
+			return "Box Art"
+	}
+	return strings.TrimPrefix(sourcePath, SDCardDirectory + "/")
+}
+
+func describeImageClass(imageClass string) string {
+	switch imageClass {
+		case ImageClassIcon:
+			return "Icon Sized"
+		case ImageClassWallpaper:
+			return "Wallpaper Sized"
+		case ImageClassListWallpaper:
+			return "List Wallpaper Sized"
+	}
+	return "Other Sizes"
+}
+
+// GroupDecorations regroups the directory aggregation for the browse modes that are not built during the scan.
+// Every decoration appears in the directory aggregation exactly once, so no decoration is listed twice
+func GroupDecorations(directoryAggregationList []models.DirectoryAggregation, mode int, sources []models.DecorationSource) []models.DecorationGroup {
+	groupedDecorations := make(map[string][]models.Decoration)
+	var groupOrder []string
+	switch mode {
+		case AggregateBySource:
+			for _, source := range sources {
+				groupOrder = append(groupOrder, DescribeDecorationSource(source.Path))
+			}
+		case AggregateByImageClass:
+			for _, imageClass := range imageClassGroupOrder {
+				groupOrder = append(groupOrder, describeImageClass(imageClass))
+			}
+		case AggregateByRecency:
+			for _, recencyGroup := range recencyGroups {
+				groupOrder = append(groupOrder, recencyGroup.name)
+			}
+	}
+
+	now := time.Now()
+	for _, directoryAggregation := range directoryAggregationList {
+		for _, decoration := range directoryAggregation.DecorationList {
+			groupName := ""
+			switch mode {
+				case AggregateBySource:
+					groupName = DescribeDecorationSource(decoration.SourcePath)
+				case AggregateByTheme:
+					groupName = findDecorationThemeName(decoration.DecorationPath)
+				case AggregateByImageClass:
+					groupName = describeImageClass(decoration.ImageClass)
+				case AggregateByRecency:
+					groupName = findRecencyGroupName(decoration.ModTime, now)
+			}
+			groupedDecorations[groupName] = append(groupedDecorations[groupName], decoration)
+		}
+	}
+
+	// Theme groups, and sources missing from the configured list, have no fixed order and sort by name
+	knownGroups := make(map[string]bool)
+	for _, groupName := range groupOrder {
+		knownGroups[groupName] = true
+	}
+	var extraGroups []string
+	for groupName := range groupedDecorations {
+		if !knownGroups[groupName] && groupName != noThemeGroupName {
+			extraGroups = append(extraGroups, groupName)
+		}
+	}
+	sort.Strings(extraGroups)
+	groupOrder = append(groupOrder, extraGroups...)
+	groupOrder = append(groupOrder, noThemeGroupName)
+
+	var decorationGroups []models.DecorationGroup
+	for _, groupName := range groupOrder {
+		decorationList := groupedDecorations[groupName]
+		if len(decorationList) == 0 {
+			continue
+		}
+		if mode == AggregateByRecency {
+			sort.SliceStable(decorationList, func(i, j int) bool {
+				return decorationList[i].ModTime > decorationList[j].ModTime
+			})
+		}
+		decorationGroups = append(decorationGroups, models.DecorationGroup{
+			GroupName:		groupName,
+			DecorationList:	decorationList,
+		})
+		delete(groupedDecorations, groupName)
+	}
+	return decorationGroups
+}
+
+func findDecorationThemeName(decorationPath string) string {
+	relativePath := strings.TrimPrefix(decorationPath, ThemesDirectory + "/")
+	if relativePath == decorationPath || !strings.Contains(relativePath, "/") {
+		return noThemeGroupName
+	}
+	return strings.SplitN(relativePath, "/", 2)[0]
+}
+
+func findRecencyGroupName(modTime int64, now time.Time) string {
+	if modTime > 0 {
+		age := now.Sub(time.Unix(modTime, 0))
+		for _, recencyGroup := range recencyGroups {
+			if recencyGroup.maxAge > 0 && age < recencyGroup.maxAge {
+				return recencyGroup.name
+			}
+		}
+	}
+	return recencyGroups[len(recencyGroups) - 1].name
+}
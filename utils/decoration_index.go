@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+)
+
+const (
+	decorationIndexVersion = 4
+)
+
+var decorationIndexPath = filepath.Join(AestheticsDirectory, "decoration_index.json")
+
+// decorationIndex persists directory listings between sessions. A listing is trusted for as long as its directory
+// modification time is unchanged, so only directories that gained or lost entries are read again. When one is read,
+// image details carry over for files whose own size and modification time are unchanged
+type decorationIndex struct {
+	Version		int									`json:"version"`
+	Directories	map[string]decorationIndexListing	`json:"directories"`
+	visited		map[string]decorationIndexListing
+	mutex		sync.Mutex
+}
+
+type decorationIndexListing struct {
+	ModTime		int64					`json:"mod_time"`
+	Entries		[]decorationIndexEntry	`json:"entries"`
+}
+
+// Only directories and png files matter to the collector, so nothing else is stored
+type decorationIndexEntry struct {
+	Name		string	`json:"name"`
+	IsDir		bool	`json:"is_dir,omitempty"`
+	Width		int		`json:"width,omitempty"`
+	Height		int		`json:"height,omitempty"`
+	HasAlpha	bool	`json:"has_alpha,omitempty"`
+	ImageClass	string	`json:"image_class,omitempty"`
+	ModTime		int64	`json:"mod_time,omitempty"`		// Unix seconds, for recency grouping
+	ModTimeNano	int64	`json:"mod_time_nano,omitempty"`	// Size and nanosecond time decide whether cached details still hold
+	Size		int64	`json:"size,omitempty"`
+}
+
+func loadDecorationIndex() *decorationIndex {
+	index := &decorationIndex{
+		Version:		decorationIndexVersion,
+		Directories:	make(map[string]decorationIndexListing),
+		visited:		make(map[string]decorationIndexListing),
+	}
+	data, err := os.ReadFile(decorationIndexPath)
+	if err != nil {
+		return index
+	}
+	var storedIndex decorationIndex
+	if err := json.Unmarshal(data, &storedIndex); err != nil || storedIndex.Version != decorationIndexVersion || storedIndex.Directories == nil {
+		return index
+	}
+	index.Directories = storedIndex.Directories
+	return index
+}
+
+// save writes only the directories visited during this scan, which prunes anything deleted since the last one
+func (index *decorationIndex) save() {
+	logger := common.GetLoggerInstance()
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+	index.Directories = index.visited
+	data, err := json.Marshal(index)
+	if err != nil {
+		logger.Error("Unable to encode decoration index", zap.Error(err))
+		return
+	}
+	EnsureDirectoryExists(filepath.Dir(decorationIndexPath))
+	if err := os.WriteFile(decorationIndexPath, data, defaultFilePerm); err != nil {
+		logger.Error("Unable to save decoration index", zap.Error(err))
+	}
+}
+
+// listDirectory returns the cached listing when the directory is unchanged, otherwise reads it fresh. A fresh read keeps
+// an image's cached details only while its own size and modification time are unchanged
+func (index *decorationIndex) listDirectory(directoryPath string) ([]decorationIndexEntry, error) {
+	stats, err := os.Stat(directoryPath)
+	if err != nil {
+		return nil, err
+	}
+	modTime := stats.ModTime().UnixNano()
+	index.mutex.Lock()
+	cachedListing, cached := index.Directories[directoryPath]
+	index.mutex.Unlock()
+
+	var entries []decorationIndexEntry
+	if cached && cachedListing.ModTime == modTime {
+		// An unchanged directory is trusted whole, so its files are not touched at all. Child directories are still
+		// checked against their own modification time when the walk reaches them
+		entries = cachedListing.Entries
+	} else {
+		files, err := GetFileList(directoryPath)
+		if err != nil {
+			return nil, err
+		}
+		cachedEntries := make(map[string]decorationIndexEntry)
+		for _, cachedEntry := range cachedListing.Entries {
+			cachedEntries[cachedEntry.Name] = cachedEntry
+		}
+		for _, file := range files {
+			if file.IsDir() || filepath.Ext(file.Name()) == ".png" {
+				entry := decorationIndexEntry{
+					Name:	file.Name(),
+					IsDir:	file.IsDir(),
+				}
+				if info, err := file.Info(); err == nil && !file.IsDir() {
+					setIndexEntryStats(&entry, info)
+				}
+				entries = append(entries, reuseIndexEntry(entry, cachedEntries))
+			}
+		}
+	}
+	index.mutex.Lock()
+	index.visited[directoryPath] = decorationIndexListing{
+		ModTime:	modTime,
+		Entries:	entries,
+	}
+	index.mutex.Unlock()
+	return entries, nil
+}
+
+func setIndexEntryStats(entry *decorationIndexEntry, info os.FileInfo) {
+	entry.Size = info.Size()
+	entry.ModTime = info.ModTime().Unix()
+	entry.ModTimeNano = info.ModTime().UnixNano()
+}
+
+// reuseIndexEntry keeps the cached details for an image whose file is unchanged
+func reuseIndexEntry(entry decorationIndexEntry, cachedEntries map[string]decorationIndexEntry) decorationIndexEntry {
+	cachedEntry, exists := cachedEntries[entry.Name]
+	if exists && !entry.IsDir && cachedEntry.Size == entry.Size && cachedEntry.ModTimeNano == entry.ModTimeNano {
+		return cachedEntry
+	}
+	return entry
+}
+
+// classify fills in image details for an entry, reading the file header only when the index has no answer yet
+func (index *decorationIndex) classify(directoryPath string, entryPosition int) decorationIndexEntry {
+	index.mutex.Lock()
+	listing := index.visited[directoryPath]
+	entry := listing.Entries[entryPosition]
+	index.mutex.Unlock()
+	if entry.ImageClass != "" {
+		return entry
+	}
+	width, height, hasAlpha, imageClass := classifyImageFile(filepath.Join(directoryPath, entry.Name))
+	entry.Width = width
+	entry.Height = height
+	entry.HasAlpha = hasAlpha
+	entry.ImageClass = imageClass
+	index.mutex.Lock()
+	listing.Entries[entryPosition] = entry
+	index.mutex.Unlock()
+	return entry
+}
+
+func DeleteDecorationIndex() bool {
+	if !DoesFileExists(decorationIndexPath) {
+		return true
+	}
+	return common.DeleteFile(decorationIndexPath)
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+)
+
+var decorationLocksPath = filepath.Join(AestheticsDirectory, "decoration_locks.json")
+
+// decorationLocks holds the device decoration files that bulk theme updates must leave alone. Each lock is the full
+// path of an icon, wallpaper, or list wallpaper, and anything skipped during an update is remembered for the report
+type decorationLocks struct {
+	Locked	map[string]bool	`json:"locked"`
+	skipped	[]string
+}
+
+func loadDecorationLocks() *decorationLocks {
+	locks := &decorationLocks{
+		Locked:	make(map[string]bool),
+	}
+	data, err := os.ReadFile(decorationLocksPath)
+	if err != nil {
+		return locks
+	}
+	var storedLocks decorationLocks
+	if err := json.Unmarshal(data, &storedLocks); err != nil || storedLocks.Locked == nil {
+		common.GetLoggerInstance().Error("Unable to read decoration locks", zap.Error(err))
+		return locks
+	}
+	locks.Locked = storedLocks.Locked
+	return locks
+}
+
+func (locks *decorationLocks) save() error {
+	data, err := json.MarshalIndent(locks, "", "  ")
+	if err != nil {
+		return err
+	}
+	EnsureDirectoryExists(filepath.Dir(decorationLocksPath))
+	return os.WriteFile(decorationLocksPath, data, defaultFilePerm)
+}
+
+// skip reports whether a device decoration is locked, noting it for the update report when it is
+func (locks *decorationLocks) skip(decorationPath string) bool {
+	if locks == nil || !locks.Locked[filepath.Clean(decorationPath)] {
+		return false
+	}
+	for _, skippedPath := range locks.skipped {
+		if skippedPath == decorationPath {
+			return true
+		}
+	}
+	locks.skipped = append(locks.skipped, decorationPath)
+	return true
+}
+
+func (locks *decorationLocks) skippedDecorations() []string {
+	if locks == nil {
+		return nil
+	}
+	return locks.skipped
+}
+
+func IsDecorationLocked(decorationPath string) bool {
+	return loadDecorationLocks().Locked[filepath.Clean(decorationPath)]
+}
+
+func SetDecorationLock(decorationPath string, locked bool) error {
+	locks := loadDecorationLocks()
+	if locked {
+		locks.Locked[filepath.Clean(decorationPath)] = true
+	} else {
+		delete(locks.Locked, filepath.Clean(decorationPath))
+	}
+	return locks.save()
+}
+
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"sync"
+
+	"go.uber.org/atomic"
+	"nextui-aesthetics/models"
+)
+
+const (
+	decorationScanWorkers = 4
+)
+
+// DecorationScanProgress is shared between a running decoration scan and the screen waiting on it
+type DecorationScanProgress struct {
+	DirectoriesScanned	atomic.Int64
+	DirectoriesFound	atomic.Int64
+	expectedDirectories	atomic.Int64
+	cancelled			atomic.Bool
+}
+
+func (progress *DecorationScanProgress) Cancel() {
+	progress.cancelled.Store(true)
+}
+
+func (progress *DecorationScanProgress) IsCancelled() bool {
+	return progress.cancelled.Load()
+}
+
+// Fraction estimates completion against the larger of the directories found so far and the directories in the last
+// saved index, which keeps the bar from racing to the end on a first scan
+func (progress *DecorationScanProgress) Fraction() float64 {
+	total := max(progress.DirectoriesFound.Load(), progress.expectedDirectories.Load())
+	if total <= 0 {
+		return 0
+	}
+	return min(1, float64(progress.DirectoriesScanned.Load()) / float64(total))
+}
+
+// decorationScanQueue tracks directories waiting to be scanned. pending counts both queued and in progress
+// directories, so workers only stop once nothing is left that could queue more
+type decorationScanQueue struct {
+	mutex	sync.Mutex
+	wake	*sync.Cond
+	tasks	[]decorationScanTask
+	pending	int
+}
+
+type decorationScanTask struct {
+	currentPath		string
+	originalParent	models.DecorationSource
+	softParentPath	string
+	hardParentPath	string
+	hardConsole		string
+	sortKey			[]int
+}
+
+type scannedDecoration struct {
+	sortKey				[]int
+	consoleTag			string
+	consoleDecoration	models.Decoration
+	directoryDecoration	models.Decoration
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"nextui-aesthetics/models"
+)
+
+// GetDecorationSources returns the configured sources, falling back to the defaults when the config has never listed any
+func GetDecorationSources(config *models.Config) []models.DecorationSource {
+	if config == nil || config.DecorationSources == nil {
+		sources := make([]models.DecorationSource, len(DefaultDecorationSources))
+		copy(sources, DefaultDecorationSources)
+		return sources
+	}
+	// Callers edit the returned list before saving it, so the config must not share its backing arrays
+	sources := make([]models.DecorationSource, len(config.DecorationSources))
+	for index, source := range config.DecorationSources {
+		source.Exclusions = append([]string(nil), source.Exclusions...)
+		sources[index] = source
+	}
+	return sources
+}
+
+func DescribeSourceTagMode(tagMode int) string {
+	switch tagMode {
+		case SourceTagsFromFilenames:
+			return "Folders & Filenames"
+		case SourceTagsIgnored:
+			return "Off"
+	}
+	return "Folders Only"
+}
+
+// isExcludedFromSource checks an entry against the source's glob exclusions. Patterns are tried against the entry
+// name and against the path relative to the source, so both "*.bak.png" and "Arcade/*" work as expected
+func isExcludedFromSource(source models.DecorationSource, entryPath string) bool {
+	if len(source.Exclusions) == 0 {
+		return false
+	}
+	entryName := filepath.Base(entryPath)
+	relativePath := strings.TrimPrefix(strings.TrimPrefix(entryPath, source.Path), "/")
+	for _, pattern := range source.Exclusions {
+		if matched, _ := filepath.Match(pattern, entryName); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, relativePath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FindOverlappingDecorationSource returns the source that is, contains, or sits inside the directory. Nested
+// sources would scan the same images twice
+func FindOverlappingDecorationSource(sources []models.DecorationSource, directoryPath string) (models.DecorationSource, bool) {
+	directoryPath = filepath.Clean(directoryPath)
+	for _, source := range sources {
+		sourcePath := filepath.Clean(source.Path)
+		if sourcePath == directoryPath || strings.HasPrefix(directoryPath, sourcePath + "/") || strings.HasPrefix(sourcePath, directoryPath + "/") {
+			return source, true
+		}
+	}
+	return models.DecorationSource{}, false
+}
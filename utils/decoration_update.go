@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"os"
+
+	"nextui-aesthetics/models"
+)
+
+// decorationUpdate carries what one theme apply, clear, or save run shares across its copies and deletions
+type decorationUpdate struct {
+	locks		*decorationLocks
+	hashes		*fileHashCache
+	progress	*ThemeUpdateProgress
+	unchanged	int
+}
+
+func newDecorationUpdate() *decorationUpdate {
+	return &decorationUpdate{
+		locks:		loadDecorationLocks(),
+		hashes:		loadFileHashCache(),
+		progress:	&ThemeUpdateProgress{},
+	}
+}
+
+// startFile reports whether the next file may be handled, naming its target for the progress screen
+func (update *decorationUpdate) startFile(filePath string) bool {
+	if update == nil {
+		return true
+	}
+	if update.progress.IsCancelled() {
+		return false
+	}
+	update.progress.currentTarget.Store(describeUpdateTarget(filePath))
+	return true
+}
+
+// finishFile counts one file toward the progress total. Passes count in the same unit their total was estimated in,
+// so apply counts theme images and clear or save count device images
+func (update *decorationUpdate) finishFile() {
+	if update == nil {
+		return
+	}
+	update.progress.FilesDone.Inc()
+}
+
+func (update *decorationUpdate) isCancelled() bool {
+	return update != nil && update.progress.IsCancelled()
+}
+
+// skipLocked reports whether a device decoration is locked and must be left alone
+func (update *decorationUpdate) skipLocked(decorationPath string) bool {
+	if update == nil {
+		return false
+	}
+	return update.locks.skip(decorationPath)
+}
+
+// skipUnchanged reports whether the destination already holds the same image as the source, counting it when so.
+// Sizes are compared first so the hash is only needed when they match
+func (update *decorationUpdate) skipUnchanged(sourcePath string, destinationPath string) bool {
+	if update == nil {
+		return false
+	}
+	sourceStats, err := os.Stat(sourcePath)
+	if err != nil {
+		return false
+	}
+	destinationStats, err := os.Stat(destinationPath)
+	if err != nil || sourceStats.Size() != destinationStats.Size() {
+		return false
+	}
+	sourceHash, err := update.hashes.hash(sourcePath)
+	if err != nil {
+		return false
+	}
+	destinationHash, err := update.hashes.hash(destinationPath)
+	if err != nil || sourceHash != destinationHash {
+		return false
+	}
+	update.unchanged++
+	return true
+}
+
+// copied records that the destination now matches the source, so the next run does not have to read it again
+func (update *decorationUpdate) copied(sourcePath string, destinationPath string) {
+	if update == nil {
+		return
+	}
+	update.hashes.copyEntry(sourcePath, destinationPath)
+}
+
+func (update *decorationUpdate) report(modifyCount int) models.ThemeUpdateReport {
+	return models.ThemeUpdateReport{
+		ModifyCount:		modifyCount,
+		UnchangedCount:		update.unchanged,
+		LockedDecorations:	update.locks.skippedDecorations(),
+		Cancelled:			update.progress.IsCancelled(),
+	}
+}
+
+func (update *decorationUpdate) finish() {
+	update.hashes.save()
+}
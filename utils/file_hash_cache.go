@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+)
+
+const (
+	fileHashCacheVersion = 1
+)
+
+var fileHashCachePath = filepath.Join(AestheticsDirectory, "hash_cache.json")
+
+// fileHashCache persists content hashes keyed by path. A hash is trusted while the file size and modification time
+// are unchanged, so re-applying a theme only reads images that were touched since the last run
+type fileHashCache struct {
+	Version	int						`json:"version"`
+	Files	map[string]fileHashEntry	`json:"files"`
+	visited	map[string]fileHashEntry
+	mutex	sync.Mutex
+}
+
+type fileHashEntry struct {
+	Size	int64	`json:"size"`
+	ModTime	int64	`json:"mod_time"`
+	Hash	string	`json:"hash"`
+}
+
+func loadFileHashCache() *fileHashCache {
+	cache := &fileHashCache{
+		Version:	fileHashCacheVersion,
+		Files:		make(map[string]fileHashEntry),
+		visited:	make(map[string]fileHashEntry),
+	}
+	data, err := os.ReadFile(fileHashCachePath)
+	if err != nil {
+		return cache
+	}
+	var storedCache fileHashCache
+	if err := json.Unmarshal(data, &storedCache); err != nil || storedCache.Version != fileHashCacheVersion || storedCache.Files == nil {
+		return cache
+	}
+	cache.Files = storedCache.Files
+	return cache
+}
+
+// hash returns the cached hash while the file is unchanged, otherwise reads the file again
+func (cache *fileHashCache) hash(filePath string) (string, error) {
+	stats, err := os.Stat(filePath)
+	if err != nil {
+		return "", err
+	}
+	cache.mutex.Lock()
+	entry, exists := cache.Files[filePath]
+	cache.mutex.Unlock()
+	if exists && entry.Size == stats.Size() && entry.ModTime == stats.ModTime().UnixNano() {
+		cache.remember(filePath, entry)
+		return entry.Hash, nil
+	}
+	fileHash, err := HashFileContents(filePath)
+	if err != nil {
+		return "", err
+	}
+	cache.remember(filePath, fileHashEntry{
+		Size:		stats.Size(),
+		ModTime:	stats.ModTime().UnixNano(),
+		Hash:		fileHash,
+	})
+	return fileHash, nil
+}
+
+// copyEntry gives a freshly copied destination the source hash, when the source hash is already known
+func (cache *fileHashCache) copyEntry(sourcePath string, destinationPath string) {
+	cache.mutex.Lock()
+	sourceEntry, exists := cache.visited[sourcePath]
+	cache.mutex.Unlock()
+	if !exists {
+		return
+	}
+	stats, err := os.Stat(destinationPath)
+	if err != nil || stats.Size() != sourceEntry.Size {
+		return
+	}
+	cache.remember(destinationPath, fileHashEntry{
+		Size:		stats.Size(),
+		ModTime:	stats.ModTime().UnixNano(),
+		Hash:		sourceEntry.Hash,
+	})
+}
+
+func (cache *fileHashCache) remember(filePath string, entry fileHashEntry) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.Files[filePath] = entry
+	cache.visited[filePath] = entry
+}
+
+// save writes every known entry, since a run that touched one theme must not forget the others. Only entries for
+// files that no longer exist are dropped
+func (cache *fileHashCache) save() {
+	logger := common.GetLoggerInstance()
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if len(cache.visited) == 0 {
+		return
+	}
+	for filePath, entry := range cache.visited {
+		cache.Files[filePath] = entry
+	}
+	for filePath := range cache.Files {
+		if _, visited := cache.visited[filePath]; visited {
+			continue
+		}
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			delete(cache.Files, filePath)
+		}
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		logger.Error("Unable to encode hash cache", zap.Error(err))
+		return
+	}
+	EnsureDirectoryExists(filepath.Dir(fileHashCachePath))
+	if err := os.WriteFile(fileHashCachePath, data, defaultFilePerm); err != nil {
+		logger.Error("Unable to save hash cache", zap.Error(err))
+	}
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -186,6 +188,20 @@ func CopyFile(sourcePath, destinationPath string) error {
 	return nil
 }
 
+// HashFileContents returns a hex sha256 of the file, used to tell identical images apart from changed ones
+func HashFileContents(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func GetSimpleFileName(fullPath string) string {
 	itemWithExt := filepath.Base(fullPath)
 	return strings.TrimSuffix(itemWithExt, filepath.Ext(itemWithExt))
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	fuzzyCharacterScore		= 10
+	fuzzyConsecutiveBonus	= 15
+	fuzzyWordStartBonus		= 10
+	fuzzySubstringBonus		= 100
+	fuzzyGapPenaltyLimit	= 20
+)
+
+// FuzzyMatch scores a query against a set of fields. Every word of the query must match at least one field, either as
+// a substring or as an in-order subsequence, and each word contributes its best field score to the total
+func FuzzyMatch(query string, fields ...string) (int, bool) {
+	totalScore := 0
+	for _, queryWord := range strings.Fields(strings.ToLower(query)) {
+		bestScore, matched := 0, false
+		for _, field := range fields {
+			if score, ok := fuzzyMatchWord(queryWord, strings.ToLower(field)); ok && (!matched || score > bestScore) {
+				bestScore, matched = score, true
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+		totalScore += bestScore
+	}
+	return totalScore, true
+}
+
+func fuzzyMatchWord(queryWord string, text string) (int, bool) {
+	if queryWord == "" {
+		return 0, true
+	}
+	textRunes := []rune(text)
+
+	// Substring matches always outrank scattered ones, and earlier and word aligned hits rank higher still
+	if position := strings.Index(text, queryWord); position >= 0 {
+		runePosition := len([]rune(text[:position]))
+		score := fuzzySubstringBonus + len([]rune(queryWord)) * (fuzzyCharacterScore + fuzzyConsecutiveBonus) - min(runePosition, fuzzyGapPenaltyLimit)
+		if isFuzzyWordStart(textRunes, runePosition) {
+			score += fuzzyWordStartBonus
+		}
+		return score, true
+	}
+
+	score := 0
+	textPosition := 0
+	previousMatch := -1
+	for _, queryRune := range queryWord {
+		found := false
+		for ; textPosition < len(textRunes); textPosition++ {
+			if textRunes[textPosition] != queryRune {
+				continue
+			}
+			score += fuzzyCharacterScore
+			if previousMatch >= 0 && textPosition == previousMatch + 1 {
+				score += fuzzyConsecutiveBonus
+			} else if previousMatch >= 0 {
+				score -= min(textPosition - previousMatch - 1, fuzzyGapPenaltyLimit)
+			}
+			if isFuzzyWordStart(textRunes, textPosition) {
+				score += fuzzyWordStartBonus
+			}
+			previousMatch = textPosition
+			textPosition++
+			found = true
+			break
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+func isFuzzyWordStart(textRunes []rune, position int) bool {
+	if position == 0 {
+		return true
+	}
+	previous := textRunes[position - 1]
+	return !unicode.IsLetter(previous) && !unicode.IsDigit(previous)
+}
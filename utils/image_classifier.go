@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"io"
+	"nextui-aesthetics/models"
+	"os"
+	"sort"
+)
+
+const (
+	iconMaxDimension		= 600
+	iconMinAspect			= 0.5
+	iconMaxAspect			= 2.0
+	wallpaperMinWidth		= 640
+	wallpaperMinAspect		= 1.2
+)
+
+// classifyImageFile reads the image header for its size. Wallpaper sized PNGs with an alpha channel also have their
+// pixel rows streamed until the first transparent one, since editors save plenty of opaque wallpapers with alpha
+func classifyImageFile(imagePath string) (width int, height int, hasAlpha bool, imageClass string) {
+	imageFile, err := os.Open(imagePath)
+	if err != nil {
+		return 0, 0, false, ImageClassUnknown
+	}
+	defer imageFile.Close()
+
+	config, format, err := image.DecodeConfig(imageFile)
+	if err != nil {
+		return 0, 0, false, ImageClassUnknown
+	}
+
+	// Transparency only changes the class of wallpaper sized images, so nothing else is read past the header
+	if colorModelHasAlpha(config.ColorModel) && classifyDimensions(config.Width, config.Height, true) == ImageClassListWallpaper {
+		if format != "png" {
+			hasAlpha = true
+		} else if _, err := imageFile.Seek(0, io.SeekStart); err == nil {
+			hasAlpha, _ = pngHasTransparentPixel(imageFile)
+		}
+	}
+	return config.Width, config.Height, hasAlpha, classifyDimensions(config.Width, config.Height, hasAlpha)
+}
+
+func classifyDimensions(width int, height int, hasAlpha bool) string {
+	if width == 0 || height == 0 {
+		return ImageClassUnknown
+	}
+	aspect := float64(width) / float64(height)
+	if max(width, height) <= iconMaxDimension && aspect >= iconMinAspect && aspect <= iconMaxAspect {
+		return ImageClassIcon
+	}
+	if width >= wallpaperMinWidth && aspect >= wallpaperMinAspect {
+		// Full screen art with transparency is almost always meant to sit behind a list
+		if hasAlpha {
+			return ImageClassListWallpaper
+		}
+		return ImageClassWallpaper
+	}
+	return ImageClassUnknown
+}
+
+// colorModelHasAlpha reports whether the format can carry transparency at all
+func colorModelHasAlpha(model color.Model) bool {
+	switch model {
+		case color.NRGBAModel, color.NRGBA64Model, color.RGBAModel, color.RGBA64Model, color.AlphaModel, color.Alpha16Model:
+			return true
+	}
+	if palette, isPalette := model.(color.Palette); isPalette {
+		for _, paletteColor := range palette {
+			if _, _, _, alpha := paletteColor.RGBA(); alpha < 0xffff {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetDecorationSuitability scores how well an image class fits a component type. Higher is better
+func GetDecorationSuitability(decoration models.Decoration, componentType string) int {
+	switch componentType {
+		case ComponentTypeIcon:
+			switch decoration.ImageClass {
+				case ImageClassIcon:
+					return 2
+				case ImageClassUnknown:
+					return 1
+			}
+		case ComponentTypeWallpaper:
+			switch decoration.ImageClass {
+				case ImageClassWallpaper:
+					return 2
+				case ImageClassListWallpaper, ImageClassUnknown:
+					return 1
+			}
+		case ComponentTypeListWallpaper:
+			switch decoration.ImageClass {
+				case ImageClassListWallpaper:
+					return 2
+				case ImageClassWallpaper, ImageClassUnknown:
+					return 1
+			}
+	}
+	return 0
+}
+
+// ArrangeDecorationsBySuitability sorts or filters a decoration list for the given component type according to the suitability mode
+func ArrangeDecorationsBySuitability(decorationList []models.Decoration, componentType string, suitabilityMode int) []models.Decoration {
+	if suitabilityMode == SuitabilityShowAll {
+		return decorationList
+	}
+	var arranged []models.Decoration
+	for _, decoration := range decorationList {
+		if suitabilityMode == SuitabilityOnly && (GetDecorationSuitability(decoration, componentType) == 0 || decoration.ImageClass == ImageClassUnknown) {
+			continue
+		}
+		arranged = append(arranged, decoration)
+	}
+	// Stable sort keeps the aggregation's own ordering within each suitability tier
+	sort.SliceStable(arranged, func(i, j int) bool {
+		return GetDecorationSuitability(arranged[i], componentType) > GetDecorationSuitability(arranged[j], componentType)
+	})
+	return arranged
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"nextui-aesthetics/models"
+	"strings"
+)
+
+const (
+	cropPreviewPath		= "/tmp/aesthetics_crop_preview.png"
+	cropOutlineWidth	= 4
+)
+
+var cropOutlineColor = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+var cropShadeColor = color.NRGBA{R: 0, G: 0, B: 0, A: 160}
+
+func IsCroppableDecoration(decorationPath string) bool {
+	return strings.HasPrefix(decorationPath, ScreenshotsDirectory)
+}
+
+// GetCropRectangle locates the selection inside the image. Icons are locked square. Wallpapers keep the
+// screenshot's own aspect ratio, which is the device screen, so a cropped wallpaper still fills the display
+func GetCropRectangle(bounds image.Rectangle, selections models.CropSelections, lockSquare bool) image.Rectangle {
+	sourceWidth := bounds.Dx()
+	sourceHeight := bounds.Dy()
+	maxWidth := sourceWidth
+	maxHeight := sourceHeight
+	if lockSquare {
+		maxWidth = min(sourceWidth, sourceHeight)
+		maxHeight = maxWidth
+	}
+
+	cropWidth := max(1, maxWidth * selections.SizePercent / 100)
+	cropHeight := max(1, maxHeight * selections.SizePercent / 100)
+	cropX := (sourceWidth - cropWidth) * selections.XPercent / 100
+	cropY := (sourceHeight - cropHeight) * selections.YPercent / 100
+	return image.Rect(cropX, cropY, cropX + cropWidth, cropY + cropHeight).Add(bounds.Min)
+}
+
+func CropImage(img image.Image, selections models.CropSelections, lockSquare bool) *image.NRGBA {
+	cropRectangle := GetCropRectangle(img.Bounds(), selections, lockSquare)
+	cropped := image.NewNRGBA(image.Rect(0, 0, cropRectangle.Dx(), cropRectangle.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, cropRectangle.Min, draw.Src)
+	return cropped
+}
+
+// GenerateCropPreview shades everything outside the selection and outlines it
+func GenerateCropPreview(sourcePath string, selections models.CropSelections, lockSquare bool) (string, error) {
+	img, err := LoadImage(sourcePath)
+	if err != nil {
+		return "", err
+	}
+	preview := toNRGBA(img)
+	cropRectangle := GetCropRectangle(preview.Bounds(), selections, lockSquare)
+
+	shade := image.NewUniform(cropShadeColor)
+	bounds := preview.Bounds()
+	for _, area := range []image.Rectangle{
+		image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Max.X, cropRectangle.Min.Y),
+		image.Rect(bounds.Min.X, cropRectangle.Max.Y, bounds.Max.X, bounds.Max.Y),
+		image.Rect(bounds.Min.X, cropRectangle.Min.Y, cropRectangle.Min.X, cropRectangle.Max.Y),
+		image.Rect(cropRectangle.Max.X, cropRectangle.Min.Y, bounds.Max.X, cropRectangle.Max.Y),
+	} {
+		draw.Draw(preview, area, shade, image.Point{}, draw.Over)
+	}
+
+	outline := image.NewUniform(cropOutlineColor)
+	for _, area := range []image.Rectangle{
+		image.Rect(cropRectangle.Min.X, cropRectangle.Min.Y, cropRectangle.Max.X, cropRectangle.Min.Y + cropOutlineWidth),
+		image.Rect(cropRectangle.Min.X, cropRectangle.Max.Y - cropOutlineWidth, cropRectangle.Max.X, cropRectangle.Max.Y),
+		image.Rect(cropRectangle.Min.X, cropRectangle.Min.Y, cropRectangle.Min.X + cropOutlineWidth, cropRectangle.Max.Y),
+		image.Rect(cropRectangle.Max.X - cropOutlineWidth, cropRectangle.Min.Y, cropRectangle.Max.X, cropRectangle.Max.Y),
+	} {
+		draw.Draw(preview, area, outline, image.Point{}, draw.Src)
+	}
+
+	if err := SavePNG(preview, cropPreviewPath); err != nil {
+		return "", err
+	}
+	return cropPreviewPath, nil
+}
+
+func SaveCroppedDecoration(sourcePath string, destinationPath string, selections models.CropSelections, lockSquare bool) error {
+	img, err := LoadImage(sourcePath)
+	if err != nil {
+		return err
+	}
+	return SavePNG(CropImage(img, selections, lockSquare), destinationPath)
+}
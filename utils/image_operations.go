@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+func LoadImage(imagePath string) (image.Image, error) {
+	imageFile, err := os.Open(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image %s: %w", imagePath, err)
+	}
+	defer imageFile.Close()
+
+	img, _, err := image.Decode(imageFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %s: %w", imagePath, err)
+	}
+	return img, nil
+}
+
+func SavePNG(img image.Image, destinationPath string) error {
+	EnsureDirectoryExists(filepath.Dir(destinationPath))
+
+	destinationFile, err := os.Create(destinationPath)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer destinationFile.Close()
+
+	if err := png.Encode(destinationFile, img); err != nil {
+		return fmt.Errorf("failed to encode png %s: %w", destinationPath, err)
+	}
+	return nil
+}
+
+// toNRGBA returns a non-premultiplied copy of the image so pixel edits never disturb alpha
+func toNRGBA(img image.Image) *image.NRGBA {
+	bounds := img.Bounds()
+	converted := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(converted, converted.Bounds(), img, bounds.Min, draw.Src)
+	return converted
+}
+
+// ScaleImage resizes with nearest neighbour sampling. Good enough for previews and icons, and keeps us dependency free
+func ScaleImage(img image.Image, width int, height int) *image.NRGBA {
+	source := toNRGBA(img)
+	sourceBounds := source.Bounds()
+	scaled := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if sourceBounds.Dx() == 0 || sourceBounds.Dy() == 0 {
+		return scaled
+	}
+	for y := 0; y < height; y++ {
+		sourceY := y * sourceBounds.Dy() / height
+		for x := 0; x < width; x++ {
+			sourceX := x * sourceBounds.Dx() / width
+			scaled.SetNRGBA(x, y, source.NRGBAAt(sourceX, sourceY))
+		}
+	}
+	return scaled
+}
+
+// ScaleImageToFit scales an image into the given box while preserving its aspect ratio. Unused space stays transparent
+func ScaleImageToFit(img image.Image, width int, height int) *image.NRGBA {
+	bounds := img.Bounds()
+	fitted := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return fitted
+	}
+	scaledWidth := width
+	scaledHeight := bounds.Dy() * width / bounds.Dx()
+	if scaledHeight > height {
+		scaledHeight = height
+		scaledWidth = bounds.Dx() * height / bounds.Dy()
+	}
+	scaled := ScaleImage(img, max(1, scaledWidth), max(1, scaledHeight))
+	offset := image.Pt((width-scaled.Bounds().Dx())/2, (height-scaled.Bounds().Dy())/2)
+	draw.Draw(fitted, scaled.Bounds().Add(offset), scaled, image.Point{}, draw.Over)
+	return fitted
+}
+
+// mapPixels applies a color transform to every visible pixel, leaving alpha untouched
+func mapPixels(img image.Image, transform func(c color.NRGBA) color.NRGBA) *image.NRGBA {
+	result := toNRGBA(img)
+	bounds := result.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := result.NRGBAAt(x, y)
+			if pixel.A == 0 {
+				continue
+			}
+			newPixel := transform(pixel)
+			newPixel.A = pixel.A
+			result.SetNRGBA(x, y, newPixel)
+		}
+	}
+	return result
+}
+
+func HueShiftImage(img image.Image, degrees float64) *image.NRGBA {
+	return mapPixels(img, func(c color.NRGBA) color.NRGBA {
+		hue, saturation, lightness := rgbToHsl(c)
+		hue = math.Mod(hue+degrees/360, 1)
+		if hue < 0 {
+			hue = hue + 1
+		}
+		return hslToRgb(hue, saturation, lightness)
+	})
+}
+
+// TintImage recolors each pixel toward the tint hue while keeping its lightness, so shading survives
+func TintImage(img image.Image, tint color.NRGBA, strength float64) *image.NRGBA {
+	tintHue, tintSaturation, _ := rgbToHsl(tint)
+	return mapPixels(img, func(c color.NRGBA) color.NRGBA {
+		_, _, lightness := rgbToHsl(c)
+		tinted := hslToRgb(tintHue, tintSaturation, lightness)
+		return blendColors(c, tinted, strength)
+	})
+}
+
+// PaletteRemapImage maps every pixel onto the palette entry closest in luminance, ordered darkest to lightest
+func PaletteRemapImage(img image.Image, palette []color.NRGBA) *image.NRGBA {
+	if len(palette) == 0 {
+		return toNRGBA(img)
+	}
+	return mapPixels(img, func(c color.NRGBA) color.NRGBA {
+		index := int(math.Round(luminance(c) * float64(len(palette)-1)))
+		return palette[index]
+	})
+}
+
+func blendColors(from color.NRGBA, to color.NRGBA, amount float64) color.NRGBA {
+	amount = math.Max(0, math.Min(1, amount))
+	mix := func(a uint8, b uint8) uint8 {
+		return uint8(math.Round(float64(a)*(1-amount) + float64(b)*amount))
+	}
+	return color.NRGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: from.A}
+}
+
+func luminance(c color.NRGBA) float64 {
+	return (0.2126*float64(c.R) + 0.7152*float64(c.G) + 0.0722*float64(c.B)) / 255
+}
+
+func rgbToHsl(c color.NRGBA) (float64, float64, float64) {
+	r := float64(c.R) / 255
+	g := float64(c.G) / 255
+	b := float64(c.B) / 255
+	maxValue := math.Max(r, math.Max(g, b))
+	minValue := math.Min(r, math.Min(g, b))
+	lightness := (maxValue + minValue) / 2
+	if maxValue == minValue {
+		return 0, 0, lightness
+	}
+
+	delta := maxValue - minValue
+	saturation := delta / (2 - maxValue - minValue)
+	if lightness <= 0.5 {
+		saturation = delta / (maxValue + minValue)
+	}
+
+	var hue float64
+	switch maxValue {
+		case r:
+			hue = (g - b) / delta
+			if g < b {
+				hue = hue + 6
+			}
+		case g:
+			hue = (b-r)/delta + 2
+		default:
+			hue = (r-g)/delta + 4
+	}
+	return hue / 6, saturation, lightness
+}
+
+func hslToRgb(hue float64, saturation float64, lightness float64) color.NRGBA {
+	if saturation == 0 {
+		gray := uint8(math.Round(lightness * 255))
+		return color.NRGBA{R: gray, G: gray, B: gray, A: 255}
+	}
+	q := lightness + saturation - lightness*saturation
+	if lightness < 0.5 {
+		q = lightness * (1 + saturation)
+	}
+	p := 2*lightness - q
+	channel := func(t float64) uint8 {
+		if t < 0 {
+			t = t + 1
+		}
+		if t > 1 {
+			t = t - 1
+		}
+		value := p
+		switch {
+			case t < 1.0/6:
+				value = p + (q-p)*6*t
+			case t < 1.0/2:
+				value = q
+			case t < 2.0/3:
+				value = p + (q-p)*(2.0/3-t)*6
+		}
+		return uint8(math.Round(value * 255))
+	}
+	return color.NRGBA{R: channel(hue + 1.0/3), G: channel(hue), B: channel(hue - 1.0/3), A: 255}
+}
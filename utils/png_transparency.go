@@ -0,0 +1,283 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	pngColorGray		= 0
+	pngColorRGB			= 2
+	pngColorPalette		= 3
+	pngColorGrayAlpha	= 4
+	pngColorRGBA		= 6
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// adam7Passes lists the x and y start and step of each interlace pass
+var adam7Passes = [][4]int{{0, 0, 8, 8}, {4, 0, 8, 8}, {0, 4, 4, 8}, {2, 0, 4, 4}, {0, 2, 2, 4}, {1, 0, 2, 2}, {0, 1, 1, 2}}
+
+type pngHeader struct {
+	width		int
+	height		int
+	bitDepth	int
+	colorType	int
+	interlaced	bool
+}
+
+// pngTransparency holds what the tRNS chunk says is see-through, for formats without an alpha channel
+type pngTransparency struct {
+	paletteAlpha	[]byte
+	colorKey		[]int
+}
+
+// pngHasTransparentPixel streams a PNG's pixel rows and stops at the first one that is not fully opaque. Only two
+// rows are held at a time, so a large opaque wallpaper never becomes a full image in memory
+func pngHasTransparentPixel(reader io.Reader) (bool, error) {
+	bufferedReader := bufio.NewReader(reader)
+	signature := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(bufferedReader, signature); err != nil || !bytes.Equal(signature, pngSignature) {
+		return false, errors.New("not a png file")
+	}
+
+	var header pngHeader
+	var transparency pngTransparency
+	for {
+		chunkLength, chunkType, err := readPNGChunkHeader(bufferedReader)
+		if err != nil {
+			return false, err
+		}
+		if chunkType == "IDAT" {
+			if !pngCanBeTransparent(header, transparency) {
+				return false, nil
+			}
+			idat := &pngDataReader{reader: bufferedReader, remaining: chunkLength}
+			imageData, err := zlib.NewReader(idat)
+			if err != nil {
+				return false, err
+			}
+			defer imageData.Close()
+			return scanPNGRows(imageData, header, transparency)
+		}
+		if chunkType == "IEND" {
+			return false, nil
+		}
+		chunkData := make([]byte, chunkLength)
+		if _, err := io.ReadFull(bufferedReader, chunkData); err != nil {
+			return false, err
+		}
+		if _, err := bufferedReader.Discard(4); err != nil {
+			return false, err
+		}
+		switch chunkType {
+			case "IHDR":
+				if len(chunkData) < 13 {
+					return false, errors.New("short png header")
+				}
+				header = pngHeader{
+					width:		int(binary.BigEndian.Uint32(chunkData[0:4])),
+					height:		int(binary.BigEndian.Uint32(chunkData[4:8])),
+					bitDepth:	int(chunkData[8]),
+					colorType:	int(chunkData[9]),
+					interlaced:	chunkData[12] == 1,
+				}
+			case "tRNS":
+				if header.colorType == pngColorPalette {
+					transparency.paletteAlpha = chunkData
+				} else {
+					for offset := 0; offset + 1 < len(chunkData); offset = offset + 2 {
+						transparency.colorKey = append(transparency.colorKey, int(binary.BigEndian.Uint16(chunkData[offset:])))
+					}
+				}
+		}
+	}
+}
+
+func readPNGChunkHeader(reader io.Reader) (int, string, error) {
+	chunkHeader := make([]byte, 8)
+	if _, err := io.ReadFull(reader, chunkHeader); err != nil {
+		return 0, "", err
+	}
+	return int(binary.BigEndian.Uint32(chunkHeader[0:4])), string(chunkHeader[4:8]), nil
+}
+
+func pngCanBeTransparent(header pngHeader, transparency pngTransparency) bool {
+	switch header.colorType {
+		case pngColorGrayAlpha, pngColorRGBA:
+			return true
+		case pngColorPalette:
+			for _, alpha := range transparency.paletteAlpha {
+				if alpha < 0xff {
+					return true
+				}
+			}
+			return false
+	}
+	return len(transparency.colorKey) > 0
+}
+
+func pngChannels(colorType int) int {
+	switch colorType {
+		case pngColorRGB:
+			return 3
+		case pngColorGrayAlpha:
+			return 2
+		case pngColorRGBA:
+			return 4
+	}
+	return 1
+}
+
+// scanPNGRows unfilters each row in turn, walking the seven interlace passes when the image has them
+func scanPNGRows(imageData io.Reader, header pngHeader, transparency pngTransparency) (bool, error) {
+	channels := pngChannels(header.colorType)
+	bitsPerPixel := channels * header.bitDepth
+	bytesPerPixel := max(1, bitsPerPixel / 8)
+	passes := [][4]int{{0, 0, 1, 1}}
+	if header.interlaced {
+		passes = adam7Passes
+	}
+	for _, pass := range passes {
+		passWidth := (header.width - pass[0] + pass[2] - 1) / pass[2]
+		passHeight := (header.height - pass[1] + pass[3] - 1) / pass[3]
+		if passWidth <= 0 || passHeight <= 0 {
+			continue
+		}
+		rowLength := (passWidth * bitsPerPixel + 7) / 8
+		row := make([]byte, rowLength + 1)
+		previousRow := make([]byte, rowLength + 1)
+		for y := 0; y < passHeight; y++ {
+			if _, err := io.ReadFull(imageData, row); err != nil {
+				return false, err
+			}
+			if err := unfilterPNGRow(row[0], row[1:], previousRow[1:], bytesPerPixel); err != nil {
+				return false, err
+			}
+			for x := 0; x < passWidth; x++ {
+				if pngPixelIsTransparent(row[1:], x, channels, header, transparency) {
+					return true, nil
+				}
+			}
+			row, previousRow = previousRow, row
+		}
+	}
+	return false, nil
+}
+
+func unfilterPNGRow(filterType byte, row []byte, previousRow []byte, bytesPerPixel int) error {
+	switch filterType {
+		case 0:
+		case 1:
+			for index := bytesPerPixel; index < len(row); index++ {
+				row[index] = row[index] + row[index - bytesPerPixel]
+			}
+		case 2:
+			for index := range row {
+				row[index] = row[index] + previousRow[index]
+			}
+		case 3:
+			for index := range row {
+				left := 0
+				if index >= bytesPerPixel {
+					left = int(row[index - bytesPerPixel])
+				}
+				row[index] = row[index] + byte((left + int(previousRow[index])) / 2)
+			}
+		case 4:
+			for index := range row {
+				var left, upLeft int
+				if index >= bytesPerPixel {
+					left = int(row[index - bytesPerPixel])
+					upLeft = int(previousRow[index - bytesPerPixel])
+				}
+				row[index] = row[index] + byte(paethPredictor(left, int(previousRow[index]), upLeft))
+			}
+		default:
+			return errors.New("unknown png filter")
+	}
+	return nil
+}
+
+func paethPredictor(left int, up int, upLeft int) int {
+	estimate := left + up - upLeft
+	leftDistance := absInt(estimate - left)
+	upDistance := absInt(estimate - up)
+	upLeftDistance := absInt(estimate - upLeft)
+	if leftDistance <= upDistance && leftDistance <= upLeftDistance {
+		return left
+	}
+	if upDistance <= upLeftDistance {
+		return up
+	}
+	return upLeft
+}
+
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// pngSample reads one channel of one pixel, unpacking bit depths below eight
+func pngSample(row []byte, pixel int, channel int, channels int, bitDepth int) int {
+	sampleIndex := pixel * channels + channel
+	switch bitDepth {
+		case 8:
+			return int(row[sampleIndex])
+		case 16:
+			return int(binary.BigEndian.Uint16(row[sampleIndex * 2:]))
+	}
+	bitOffset := sampleIndex * bitDepth
+	return int(row[bitOffset / 8] >> (8 - bitDepth - bitOffset % 8)) & (1 << bitDepth - 1)
+}
+
+func pngPixelIsTransparent(row []byte, pixel int, channels int, header pngHeader, transparency pngTransparency) bool {
+	switch header.colorType {
+		case pngColorGrayAlpha, pngColorRGBA:
+			return pngSample(row, pixel, channels - 1, channels, header.bitDepth) < 1 << header.bitDepth - 1
+		case pngColorPalette:
+			paletteIndex := pngSample(row, pixel, 0, channels, header.bitDepth)
+			return paletteIndex < len(transparency.paletteAlpha) && transparency.paletteAlpha[paletteIndex] < 0xff
+	}
+	if len(transparency.colorKey) < channels {
+		return false
+	}
+	sampleMask := 1 << header.bitDepth - 1
+	for channel := 0; channel < channels; channel++ {
+		if pngSample(row, pixel, channel, channels, header.bitDepth) != transparency.colorKey[channel] & sampleMask {
+			return false
+		}
+	}
+	return true
+}
+
+// pngDataReader joins consecutive IDAT chunks into one stream, skipping each chunk's CRC
+type pngDataReader struct {
+	reader		*bufio.Reader
+	remaining	int
+}
+
+func (idat *pngDataReader) Read(buffer []byte) (int, error) {
+	for idat.remaining == 0 {
+		if _, err := idat.reader.Discard(4); err != nil {
+			return 0, err
+		}
+		chunkLength, chunkType, err := readPNGChunkHeader(idat.reader)
+		if err != nil {
+			return 0, err
+		}
+		if chunkType != "IDAT" {
+			return 0, io.EOF
+		}
+		idat.remaining = chunkLength
+	}
+	readLength, err := idat.reader.Read(buffer[:min(len(buffer), idat.remaining)])
+	idat.remaining = idat.remaining - readLength
+	return readLength, err
+}
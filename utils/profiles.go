@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+
+	"nextui-aesthetics/models"
+)
+
+const (
+	profileThemePrefix	= "Profile-"
+	profileSavingPrefix	= ".saving-"
+)
+
+var profileStatePath = filepath.Join(AestheticsDirectory, "profiles.json")
+
+// profileState remembers which profile the device is showing. Profiles themselves are ordinary local themes whose
+// folder names carry the profile prefix, so they can be browsed and applied like any other theme
+type profileState struct {
+	ActiveProfile	string	`json:"active_profile"`
+}
+
+func loadProfileState() profileState {
+	var state profileState
+	data, err := os.ReadFile(profileStatePath)
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		common.GetLoggerInstance().Error("Unable to read profile state", zap.Error(err))
+		return profileState{}
+	}
+	return state
+}
+
+func (state profileState) save() error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	EnsureDirectoryExists(filepath.Dir(profileStatePath))
+	return os.WriteFile(profileStatePath, data, defaultFilePerm)
+}
+
+// GetProfiles lists the saved profile names
+func GetProfiles() []string {
+	var profiles []string
+	files, err := GetFileList(ThemesDirectory)
+	if err != nil {
+		return profiles
+	}
+	for _, file := range files {
+		if file.IsDir() && strings.HasPrefix(file.Name(), profileThemePrefix) {
+			profiles = append(profiles, strings.TrimPrefix(file.Name(), profileThemePrefix))
+		}
+	}
+	return profiles
+}
+
+// GetActiveProfile names the profile the device is showing, or gives an empty name when none is active
+func GetActiveProfile() string {
+	activeProfile := loadProfileState().ActiveProfile
+	if activeProfile != "" && !DoesFileExists(ProfileTheme(activeProfile).ThemePath) {
+		return ""
+	}
+	return activeProfile
+}
+
+func ProfileTheme(profileName string) models.Theme {
+	return models.Theme{
+		ThemeName:	profileThemePrefix + profileName,
+		ThemePath:	filepath.Join(ThemesDirectory, profileThemePrefix + profileName),
+	}
+}
+
+// ValidateProfileName rejects names that cannot be a folder or that another profile already has
+func ValidateProfileName(profileName string) error {
+	if strings.TrimSpace(profileName) == "" || strings.ContainsAny(profileName, "/\\") || strings.HasPrefix(profileName, ".") {
+		return errors.New("profile names cannot be empty, start with a dot, or contain slashes")
+	}
+	if DoesFileExists(ProfileTheme(profileName).ThemePath) {
+		return errors.New("a profile named " + profileName + " already exists")
+	}
+	return nil
+}
+
+// SaveProfile saves everything on the device into a profile, replacing its earlier contents, and marks it active
+func SaveProfile(profileName string) (models.ThemeUpdateReport, error) {
+	update := newDecorationUpdate()
+	defer update.finish()
+	deviceComponents := GetThemeComponents(models.Theme{})
+	modifyCount, err := runThemeUpdateStep("Saving profile " + profileName, update, func() (int, error) {
+		update.progress.startStep(countDeviceDecorationFiles(deviceComponents))
+		return saveProfileSnapshot(profileName, deviceComponents, update)
+	})
+	if err != nil || update.isCancelled() {
+		return update.report(modifyCount), err
+	}
+	return update.report(modifyCount), profileState{ActiveProfile: profileName}.save()
+}
+
+// SwitchProfile saves the device into the active profile, clears every decoration, and applies the incoming profile.
+// Locked decorations are left alone by all three steps. No profile is active between the save and the end of the apply
+func SwitchProfile(profileName string) (models.ThemeUpdateReport, error) {
+	update := newDecorationUpdate()
+	defer update.finish()
+	modifyCount := 0
+	options := models.ComponentOptionSelections{OptionAll: true}
+	deviceComponents := GetThemeComponents(models.Theme{})
+
+	if activeProfile := GetActiveProfile(); activeProfile != "" {
+		count, err := runThemeUpdateStep("Saving profile " + activeProfile, update, func() (int, error) {
+			update.progress.startStep(countDeviceDecorationFiles(deviceComponents))
+			return saveProfileSnapshot(activeProfile, deviceComponents, update)
+		})
+		modifyCount = modifyCount + count
+		if err != nil || update.isCancelled() {
+			return update.report(modifyCount), err
+		}
+	}
+	// The device matches no profile until the incoming one is fully applied, so a failed switch cannot later save a
+	// half cleared device over the outgoing profile
+	if err := (profileState{}).save(); err != nil {
+		return update.report(modifyCount), err
+	}
+
+	count, err := runThemeUpdateStep("Clearing decorations", update, func() (int, error) {
+		update.progress.startStep(countDeviceDecorationFiles(deviceComponents))
+		return resetToDefaultRequestedComponents(deviceComponents, options, update)
+	})
+	modifyCount = modifyCount + count
+	if err != nil || update.isCancelled() {
+		return update.report(modifyCount), err
+	}
+
+	theme := ProfileTheme(profileName)
+	themeComponents := GetThemeComponents(theme)
+	count, err = runThemeUpdateStep("Applying profile " + profileName, update, func() (int, error) {
+		update.progress.startStep(countThemeComponentFiles(themeComponents))
+		return applySelectedThemeComponents(theme, themeComponents, options, update)
+	})
+	modifyCount = modifyCount + count
+	if err != nil || update.isCancelled() {
+		return update.report(modifyCount), err
+	}
+	return update.report(modifyCount), profileState{ActiveProfile: profileName}.save()
+}
+
+// DeleteProfile removes a profile, leaving the device as it is
+func DeleteProfile(profileName string) error {
+	if err := os.RemoveAll(ProfileTheme(profileName).ThemePath); err != nil {
+		return err
+	}
+	if loadProfileState().ActiveProfile == profileName {
+		return profileState{}.save()
+	}
+	return nil
+}
+
+// saveProfileSnapshot saves the device into a hidden folder first and only then swaps it in for the profile, so a
+// failed or cancelled save keeps the profile's earlier contents
+func saveProfileSnapshot(profileName string, deviceComponents []models.Component, update *decorationUpdate) (int, error) {
+	profileTheme := ProfileTheme(profileName)
+	savingName := profileSavingPrefix + profileTheme.ThemeName
+	savingPath := filepath.Join(ThemesDirectory, savingName)
+	os.RemoveAll(savingPath)
+	if err := EnsureDirectoryExists(savingPath); err != nil {
+		return 0, err
+	}
+
+	modifyCount, err := saveCurrentTheme(deviceComponents, models.ComponentOptionSelections{OptionAll: true}, savingName, update)
+	if err != nil || update.isCancelled() {
+		os.RemoveAll(savingPath)
+		return modifyCount, err
+	}
+	if err := os.RemoveAll(profileTheme.ThemePath); err != nil {
+		return modifyCount, err
+	}
+	return modifyCount, os.Rename(savingPath, profileTheme.ThemePath)
+}
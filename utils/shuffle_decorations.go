@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"nextui-aesthetics/models"
+)
+
+const shuffleThemeBaseName = "Shuffle"
+
+// shuffleSeedLimit keeps generated seeds short enough to note down and type back in
+const shuffleSeedLimit = 1000000
+
+// shuffleTarget is one folder decoration a shuffle fills
+type shuffleTarget struct {
+	folderPath		string
+	consoleTag		string
+	componentType	string
+	destinationPath	string
+	replaces		bool
+}
+
+// NewShuffleSeed gives a fresh seed for a shuffle
+func NewShuffleSeed() int64 {
+	return time.Now().UnixNano() % shuffleSeedLimit
+}
+
+// PlanShuffle draws a random decoration of each chosen type for every chosen console folder, from the images sized
+// for that type aggregated on the folder's console tag. Candidates are sorted and each target draws from its own seed,
+// so a seed always picks the same image for a folder whatever else is chosen. Locked decorations are left out and
+// returned for the report
+func PlanShuffle(consoleAggregation []models.ConsoleAggregation, selections models.ShuffleSelections) ([]models.DecorationPlanEntry, []string, error) {
+	var plan []models.DecorationPlanEntry
+	targets, lockedDecorations, err := collectShuffleTargets(selections)
+	if err != nil {
+		return plan, lockedDecorations, err
+	}
+
+	candidates := make(map[string][]string)
+	for _, aggregation := range consoleAggregation {
+		for _, decoration := range aggregation.DecorationList {
+			poolKey := aggregation.ConsoleTag + decoration.ImageClass
+			candidates[poolKey] = append(candidates[poolKey], decoration.DecorationPath)
+		}
+	}
+	for poolKey := range candidates {
+		sort.Strings(candidates[poolKey])
+	}
+
+	for _, target := range targets {
+		pool := candidates[target.consoleTag + target.componentType]
+		if len(pool) == 0 {
+			continue
+		}
+		random := rand.New(rand.NewSource(shuffleTargetSeed(selections.Seed, target)))
+		plan = append(plan, models.DecorationPlanEntry{
+			FolderPath:			target.folderPath,
+			ImagePath:			pool[random.Intn(len(pool))],
+			DestinationPath:	target.destinationPath,
+			Replaces:			target.replaces,
+		})
+	}
+	return plan, lockedDecorations, nil
+}
+
+// shuffleTargetSeed derives a target's seed from the shuffle seed, its folder, and its component type
+func shuffleTargetSeed(seed int64, target shuffleTarget) int64 {
+	hasher := fnv.New64a()
+	binary.Write(hasher, binary.LittleEndian, seed)
+	hasher.Write([]byte(target.folderPath))
+	hasher.Write([]byte{0})
+	hasher.Write([]byte(target.componentType))
+	return int64(hasher.Sum64())
+}
+
+// SaveShuffleAsTheme writes a shuffle plan into a new local theme named after its seed
+func SaveShuffleAsTheme(plan []models.DecorationPlanEntry, seed int64) (models.Theme, int, error) {
+	themeName, err := generateVariantThemeName(fmt.Sprintf("%s %d", shuffleThemeBaseName, seed))
+	if err != nil {
+		return models.Theme{}, 0, err
+	}
+	theme := models.Theme{
+		ThemeName:	themeName,
+		ThemePath:	filepath.Join(ThemesDirectory, themeName),
+	}
+	if err := EnsureDirectoryExists(theme.ThemePath); err != nil {
+		return theme, 0, err
+	}
+
+	// Console slots are matched to plan entries by the device file they stand for
+	slots, err := GetThemeSlots(theme, models.ThemeSlot{})
+	if err != nil {
+		return theme, 0, err
+	}
+	slotsByDestination := make(map[string]models.ThemeSlot)
+	for _, slot := range slots {
+		if slot.TargetGroup == CoverageGroupConsoles {
+			slotsByDestination[FolderDecorationPath(slot.TargetPath, slot.ComponentType)] = slot
+		}
+	}
+	saveCount := 0
+	for _, entry := range plan {
+		slot, exists := slotsByDestination[entry.DestinationPath]
+		if !exists {
+			continue
+		}
+		if err := SetThemeSlotImage(slot, entry.ImagePath); err != nil {
+			return theme, saveCount, err
+		}
+		saveCount++
+	}
+	return theme, saveCount, nil
+}
+
+// collectShuffleTargets lists the folder decorations a shuffle fills, ordered by folder and then by component type
+func collectShuffleTargets(selections models.ShuffleSelections) ([]shuffleTarget, []string, error) {
+	var targets []shuffleTarget
+	parentsList, err := getTopLevelRomsDirectories(false)
+	if err != nil {
+		return targets, nil, err
+	}
+	chosenTags := make(map[string]bool)
+	for _, consoleTag := range selections.ConsoleTags {
+		chosenTags[consoleTag] = true
+	}
+
+	locks := loadDecorationLocks()
+	for _, parent := range parentsList {
+		consoleTag := FindConsoleTag(parent.Filename)
+		if consoleTag == "" || (len(chosenTags) > 0 && !chosenTags[consoleTag]) {
+			continue
+		}
+		folderPath := filepath.Join(GetRomDirectory(), parent.Filename)
+		for _, componentType := range selections.ComponentTypes {
+			destinationPath := FolderDecorationPath(folderPath, componentType)
+			if destinationPath == "" {
+				continue
+			}
+			replaces := DoesFileExists(destinationPath)
+			if (selections.MissingOnly && replaces) || locks.skip(destinationPath) {
+				continue
+			}
+			targets = append(targets, shuffleTarget{
+				folderPath:			folderPath,
+				consoleTag:			consoleTag,
+				componentType:		componentType,
+				destinationPath:	destinationPath,
+				replaces:			replaces,
+			})
+		}
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].folderPath < targets[j].folderPath
+	})
+	return targets, locks.skippedDecorations(), nil
+}
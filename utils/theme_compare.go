@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"path/filepath"
+	"sort"
+
+	"nextui-aesthetics/models"
+)
+
+const (
+	ComparisonIdentical	= "Identical"
+	ComparisonDifferent	= "Different"
+	ComparisonNew		= "New"
+	ComparisonAbsent	= "Absent"
+)
+
+// ComparisonStatuses lists the statuses in the order summaries show them
+var ComparisonStatuses = []string{ComparisonDifferent, ComparisonNew, ComparisonAbsent, ComparisonIdentical}
+
+// coverageComponentNames names the theme component holding each coverage group and component type
+var coverageComponentNames = map[string]map[string]string{
+	CoverageGroupConsoles: {
+		ComponentTypeIcon:			"SystemIcons",
+		ComponentTypeWallpaper:		"SystemWallpapers",
+		ComponentTypeListWallpaper:	"SystemListWallpapers",
+	},
+	CoverageGroupMenus: {
+		ComponentTypeIcon:			"SystemIcons",
+		ComponentTypeWallpaper:		"SystemWallpapers",
+		ComponentTypeListWallpaper:	"SystemListWallpapers",
+	},
+	CoverageGroupCollections: {
+		ComponentTypeIcon:			"CollectionIcons",
+		ComponentTypeWallpaper:		"CollectionWallpapers",
+		ComponentTypeListWallpaper:	"CollectionListWallpapers",
+	},
+	CoverageGroupTools: {
+		ComponentTypeIcon:			"ToolIcons",
+		ComponentTypeWallpaper:		"ToolWallpapers",
+		ComponentTypeListWallpaper:	"ToolListWallpapers",
+	},
+}
+
+// themeDecorationTarget is one theme image and the device path applying the theme would copy it to
+type themeDecorationTarget struct {
+	componentName	string
+	sourcePath		string
+	destinationPath	string
+}
+
+// CompareThemes classifies every device path the theme would write against the same path on the device, or against
+// what baseTheme would write there. Paths only the base has an image for are reported as absent from the theme
+func CompareThemes(theme models.Theme, baseTheme models.Theme) ([]models.ComponentComparison, error) {
+	validParents, err := collectAllRomParents()
+	if err != nil {
+		return nil, err
+	}
+	themeTargets := collectThemeDecorationTargets(theme, validParents)
+
+	// Gather what the base holds at each device path, grouped under the component it belongs to
+	var baseTargets []themeDecorationTarget
+	baseIsDevice := IsCurrentTheme(baseTheme)
+	if baseIsDevice {
+		baseTargets, err = collectDeviceDecorationTargets()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		baseTargets = collectThemeDecorationTargets(baseTheme, validParents)
+	}
+	baseImages := make(map[string]string)
+	for _, baseTarget := range baseTargets {
+		if _, exists := baseImages[baseTarget.destinationPath]; !exists {
+			baseImages[baseTarget.destinationPath] = baseTarget.sourcePath
+		}
+	}
+
+	comparisons := make(map[string][]models.DecorationComparison)
+	comparedPaths := make(map[string]bool)
+	for _, themeTarget := range themeTargets {
+		comparison := models.DecorationComparison{
+			TargetPath:		themeTarget.destinationPath,
+			ThemeImagePath:	themeTarget.sourcePath,
+			BaseImagePath:	baseImages[themeTarget.destinationPath],
+		}
+		// Nested folders are not in the device listing, so check the device path itself
+		if baseIsDevice && comparison.BaseImagePath == "" && DoesFileExists(themeTarget.destinationPath) {
+			comparison.BaseImagePath = themeTarget.destinationPath
+		}
+		comparison.Status = classifyDecorationComparison(comparison.ThemeImagePath, comparison.BaseImagePath)
+		comparisons[themeTarget.componentName] = append(comparisons[themeTarget.componentName], comparison)
+		comparedPaths[themeTarget.destinationPath] = true
+	}
+	for _, baseTarget := range baseTargets {
+		if comparedPaths[baseTarget.destinationPath] {
+			continue
+		}
+		comparisons[baseTarget.componentName] = append(comparisons[baseTarget.componentName], models.DecorationComparison{
+			TargetPath:		baseTarget.destinationPath,
+			BaseImagePath:	baseTarget.sourcePath,
+			Status:			ComparisonAbsent,
+		})
+		comparedPaths[baseTarget.destinationPath] = true
+	}
+
+	var componentNames []string
+	for componentName := range comparisons {
+		componentNames = append(componentNames, componentName)
+	}
+	sort.Strings(componentNames)
+	var componentComparisons []models.ComponentComparison
+	for _, componentName := range componentNames {
+		componentComparisons = append(componentComparisons, models.ComponentComparison{
+			ComponentName:	componentName,
+			Comparisons:	comparisons[componentName],
+		})
+	}
+	return componentComparisons, nil
+}
+
+// CountComparisons tallies a component's comparisons by status
+func CountComparisons(componentComparison models.ComponentComparison) map[string]int {
+	counts := make(map[string]int)
+	for _, comparison := range componentComparison.Comparisons {
+		counts[comparison.Status]++
+	}
+	return counts
+}
+
+func classifyDecorationComparison(themeImagePath string, baseImagePath string) string {
+	if baseImagePath == "" {
+		return ComparisonNew
+	}
+	themeHash, themeErr := HashFileContents(themeImagePath)
+	baseHash, baseErr := HashFileContents(baseImagePath)
+	if themeErr == nil && baseErr == nil && themeHash == baseHash {
+		return ComparisonIdentical
+	}
+	return ComparisonDifferent
+}
+
+// collectAllRomParents groups every top level rom directory by console tag, as an unrestricted apply would
+func collectAllRomParents() (map[string][]string, error) {
+	validParents := make(map[string][]string)
+	parentsList, err := getTopLevelRomsDirectories(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range parentsList {
+		parentConsole := FindConsoleTag(parent.Filename)
+		validParents[parentConsole] = append(validParents[parentConsole], parent.Filename)
+	}
+	return validParents, nil
+}
+
+// collectThemeDecorationTargets resolves theme images to device paths the same way applying the theme does
+func collectThemeDecorationTargets(theme models.Theme, validParents map[string][]string) []themeDecorationTarget {
+	var targets []themeDecorationTarget
+	for _, component := range GetThemeComponents(theme) {
+		romParentSet := make(map[string]bool)
+		for _, componentPath := range component.ComponentPaths {
+			files, err := GetFileList(componentPath)
+			if err != nil {
+				continue
+			}
+			for _, file := range files {
+				itemName := file.Name()
+				if filepath.Ext(itemName) != ".png" {
+					continue
+				}
+				sourcePath := filepath.Join(componentPath, itemName)
+				var destinations []string
+				if metaDestination, isMeta := themeMetaDestination(component, itemName); isMeta {
+					destinations = []string{metaDestination}
+				} else {
+					destinations = resolveThemeDecorationDestinations(component, itemName, validParents, romParentSet, models.ComponentOptionSelections{})
+				}
+				for _, destinationPath := range destinations {
+					targets = append(targets, themeDecorationTarget{
+						componentName:		component.ComponentName,
+						sourcePath:			sourcePath,
+						destinationPath:	destinationPath,
+					})
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// collectDeviceDecorationTargets lists the decorations on the device for every target the coverage report knows
+func collectDeviceDecorationTargets() ([]themeDecorationTarget, error) {
+	coverageTargets, err := collectCoverageTargets()
+	if err != nil {
+		return nil, err
+	}
+	var targets []themeDecorationTarget
+	for _, coverageTarget := range coverageTargets {
+		for _, componentType := range CoverageComponentTypes {
+			if _, applicable := coverageTarget.row.Coverage[componentType]; !applicable {
+				continue
+			}
+			devicePath := coverageTarget.devicePath(componentType)
+			if devicePath == "" || !DoesFileExists(devicePath) {
+				continue
+			}
+			targets = append(targets, themeDecorationTarget{
+				componentName:		coverageComponentNames[coverageTarget.row.TargetGroup][componentType],
+				sourcePath:			devicePath,
+				destinationPath:	devicePath,
+			})
+		}
+	}
+	return targets, nil
+}
@@ -0,0 +1,337 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"nextui-aesthetics/models"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+)
+
+const (
+	composedThemeBaseName	= "Composed Theme"
+	themeRecipeName			= "recipe.json"
+	composingThemePrefix	= ".composing-"
+)
+
+// ComposeComponentNames lists the components a recipe can source, in the order the composer shows them
+var ComposeComponentNames = []string{
+	"SystemIcons",
+	"SystemWallpapers",
+	"SystemListWallpapers",
+	"CollectionIcons",
+	"CollectionWallpapers",
+	"CollectionListWallpapers",
+	"ToolIcons",
+	"ToolWallpapers",
+	"ToolListWallpapers",
+}
+
+// ComposeTheme builds a theme from the recipe's sources and stores the recipe beside it. A recipe naming an existing
+// theme regenerates it, replacing its components with fresh copies from the sources. The new copy is built in a hidden
+// folder and only swapped in once complete, so a failed regenerate keeps the old theme
+func ComposeTheme(recipe models.ThemeRecipe) (models.Theme, int, error) {
+	downloadedThemes := GetDownloadedThemes()
+	sourceThemes := make(map[string]models.Theme)
+	for _, sourceName := range recipeSourceNames(recipe) {
+		sourceTheme, exists := downloadedThemes[sourceName]
+		if !exists || !sourceTheme.ContainsTheme {
+			return models.Theme{}, 0, fmt.Errorf("source theme %s is missing", sourceName)
+		}
+		sourceThemes[sourceName] = sourceTheme
+	}
+	if len(sourceThemes) == 0 {
+		return models.Theme{}, 0, fmt.Errorf("no source themes chosen")
+	}
+
+	if recipe.ThemeName == "" {
+		themeName, err := generateVariantThemeName(composedThemeBaseName)
+		if err != nil {
+			return models.Theme{}, 0, err
+		}
+		recipe.ThemeName = themeName
+	}
+	themePath := filepath.Join(ThemesDirectory, recipe.ThemeName)
+
+	// Components start empty so images dropped from a source do not linger after regenerating
+	buildPath := filepath.Join(ThemesDirectory, composingThemePrefix + recipe.ThemeName)
+	os.RemoveAll(buildPath)
+	if err := EnsureDirectoryExists(buildPath); err != nil {
+		return models.Theme{}, 0, err
+	}
+	composeCount, err := buildComposedTheme(recipe, sourceThemes, buildPath)
+	if err == nil {
+		err = carryOverThemeExtras(themePath, buildPath)
+	}
+	if err != nil {
+		os.RemoveAll(buildPath)
+		return models.Theme{}, composeCount, err
+	}
+	if err := os.RemoveAll(themePath); err != nil {
+		return models.Theme{}, composeCount, err
+	}
+	if err := os.Rename(buildPath, themePath); err != nil {
+		return models.Theme{}, composeCount, err
+	}
+
+	return models.Theme{
+		ThemeName:		recipe.ThemeName,
+		ThemePath:		themePath,
+		PreviewFound:	DoesFileExists(filepath.Join(themePath, previewStandardName)),
+		ContainsTheme:	true,
+	}, composeCount, nil
+}
+
+// buildComposedTheme copies every recipe source into the theme folder, then adds the merged preview and the recipe
+func buildComposedTheme(recipe models.ThemeRecipe, sourceThemes map[string]models.Theme, themePath string) (int, error) {
+	logger := common.GetLoggerInstance()
+	composeCount := 0
+
+	for _, componentName := range ComposeComponentNames {
+		sourceName, exists := recipe.Components[componentName]
+		if !exists {
+			continue
+		}
+		count, err := copyComposedComponent(sourceThemes[sourceName], componentName, themePath, func(consoleTag string) bool {
+			_, overridden := recipe.Consoles[consoleTag]
+			return !overridden
+		})
+		composeCount = composeCount + count
+		if err != nil {
+			return composeCount, err
+		}
+	}
+
+	// Console sources fill in every rom component for their console, whichever source the component uses otherwise
+	for consoleTag, sourceName := range recipe.Consoles {
+		for _, componentName := range ComposeComponentNames {
+			if !checkComponentForRomsDependency(ComponentTypes[componentName].ComponentHomeDirectory) {
+				continue
+			}
+			count, err := copyComposedComponent(sourceThemes[sourceName], componentName, themePath, func(itemConsoleTag string) bool {
+				return itemConsoleTag == consoleTag
+			})
+			composeCount = composeCount + count
+			if err != nil {
+				return composeCount, err
+			}
+		}
+	}
+
+	var previewPaths []string
+	for _, sourceName := range recipeSourceNames(recipe) {
+		if previewPath := GetPreviewPath(sourceName); previewPath != "" {
+			previewPaths = append(previewPaths, previewPath)
+		}
+	}
+	if err := mergeThemePreviews(previewPaths, filepath.Join(themePath, previewStandardName)); err != nil {
+		logger.Error("Unable to generate composed preview", zap.Error(err))
+	}
+
+	return composeCount, saveThemeRecipe(themePath, recipe)
+}
+
+// carryOverThemeExtras copies anything a regenerate does not rebuild, such as notes shipped with the theme, from the
+// old theme into the new one. Component folders, previews, and the recipe are left behind
+func carryOverThemeExtras(themePath string, buildPath string) error {
+	themeContents, err := os.ReadDir(themePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, item := range themeContents {
+		itemName := item.Name()
+		if _, isComponent := ComponentTypes[itemName]; isComponent || itemName == previewStandardName || itemName == previewHiddenName || itemName == themeRecipeName {
+			continue
+		}
+		itemPath := filepath.Join(themePath, itemName)
+		err := filepath.WalkDir(itemPath, func(sourcePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			relativePath, err := filepath.Rel(themePath, sourcePath)
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return EnsureDirectoryExists(filepath.Join(buildPath, relativePath))
+			}
+			return CopyFile(sourcePath, filepath.Join(buildPath, relativePath))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+
+// LoadThemeRecipe reads the recipe a composed theme was built from. Themes that were not composed have none
+func LoadThemeRecipe(theme models.Theme) (models.ThemeRecipe, bool) {
+	var recipe models.ThemeRecipe
+	data, err := os.ReadFile(filepath.Join(theme.ThemePath, themeRecipeName))
+	if err != nil {
+		return recipe, false
+	}
+	if err := json.Unmarshal(data, &recipe); err != nil {
+		common.GetLoggerInstance().Error("Unable to read theme recipe", zap.String("theme", theme.ThemeName), zap.Error(err))
+		return recipe, false
+	}
+	// The folder name wins if the theme was renamed since it was composed
+	recipe.ThemeName = theme.ThemeName
+	return recipe, true
+}
+
+// SetRecipeSource returns a copy of the recipe with one component or console source changed. An empty source removes it
+func SetRecipeSource(recipe models.ThemeRecipe, componentName string, consoleTag string, sourceName string) models.ThemeRecipe {
+	updated := models.ThemeRecipe{
+		ThemeName:	recipe.ThemeName,
+		Components:	make(map[string]string),
+		Consoles:	make(map[string]string),
+	}
+	for key, value := range recipe.Components {
+		updated.Components[key] = value
+	}
+	for key, value := range recipe.Consoles {
+		updated.Consoles[key] = value
+	}
+	sources := updated.Components
+	key := componentName
+	if consoleTag != "" {
+		sources = updated.Consoles
+		key = consoleTag
+	}
+	if sourceName == "" {
+		delete(sources, key)
+	} else {
+		sources[key] = sourceName
+	}
+	return updated
+}
+
+// ThemeSupportsComponent reports whether a theme has images a recipe could take for the component
+func ThemeSupportsComponent(theme models.Theme, componentName string) bool {
+	for _, component := range GetThemeComponents(theme) {
+		if component.IsSupported && composeComponentMatches(component, componentName) {
+			return true
+		}
+	}
+	return false
+}
+
+// recipeSourceNames lists each source theme once, components first, in a stable order
+func recipeSourceNames(recipe models.ThemeRecipe) []string {
+	var sourceNames []string
+	seen := make(map[string]bool)
+	for _, componentName := range ComposeComponentNames {
+		if sourceName, exists := recipe.Components[componentName]; exists && !seen[sourceName] {
+			seen[sourceName] = true
+			sourceNames = append(sourceNames, sourceName)
+		}
+	}
+	var consoleTags []string
+	for consoleTag := range recipe.Consoles {
+		consoleTags = append(consoleTags, consoleTag)
+	}
+	sort.Strings(consoleTags)
+	for _, consoleTag := range consoleTags {
+		if sourceName := recipe.Consoles[consoleTag]; !seen[sourceName] {
+			seen[sourceName] = true
+			sourceNames = append(sourceNames, sourceName)
+		}
+	}
+	return sourceNames
+}
+
+// composeComponentMatches also accepts duplicate component folders, so themes using ListWallpapers still count
+func composeComponentMatches(component models.Component, componentName string) bool {
+	if component.ComponentName == componentName {
+		return true
+	}
+	target := ComponentTypes[componentName]
+	return component.ComponentType.DuplicateType &&
+		component.ComponentType.ComponentType == target.ComponentType &&
+		component.ComponentType.ComponentHomeDirectory == target.ComponentHomeDirectory
+}
+
+// copyComposedComponent copies a source theme's images for one component into the composed theme. Rom images are
+// only copied when include accepts their console tag. Meta images have no console and follow the component source
+func copyComposedComponent(sourceTheme models.Theme, componentName string, themePath string, include func(consoleTag string) bool) (int, error) {
+	copyCount := 0
+	isRomDependent := checkComponentForRomsDependency(ComponentTypes[componentName].ComponentHomeDirectory)
+	destinationDirectory := filepath.Join(themePath, componentName)
+	for _, component := range GetThemeComponents(sourceTheme) {
+		if !composeComponentMatches(component, componentName) {
+			continue
+		}
+		for _, componentPath := range component.ComponentPaths {
+			files, err := GetFileList(componentPath)
+			if err != nil {
+				continue
+			}
+			for _, file := range files {
+				itemName := file.Name()
+				if file.IsDir() || filepath.Ext(itemName) != ".png" {
+					continue
+				}
+				if isRomDependent {
+					consoleTag := ""
+					if _, isMeta := themeMetaFileTargets[itemName]; !isMeta {
+						consoleTag = FindConsoleTag(strings.Split(strings.TrimSuffix(itemName, ".png"), folderDelimiter)[0])
+					}
+					if !include(consoleTag) {
+						continue
+					}
+				}
+				if err := EnsureDirectoryExists(destinationDirectory); err != nil {
+					return copyCount, err
+				}
+				if err := CopyFile(filepath.Join(componentPath, itemName), filepath.Join(destinationDirectory, itemName)); err != nil {
+					return copyCount, err
+				}
+				copyCount++
+			}
+		}
+	}
+	return copyCount, nil
+}
+
+// mergeThemePreviews builds a preview from vertical strips of each source preview, left to right in source order
+func mergeThemePreviews(previewPaths []string, destinationPath string) error {
+	var previews []image.Image
+	for _, previewPath := range previewPaths {
+		img, err := LoadImage(previewPath)
+		if err != nil {
+			continue
+		}
+		previews = append(previews, ScaleImage(img, generatedPreviewWidth, generatedPreviewHeight))
+	}
+	if len(previews) == 0 {
+		return fmt.Errorf("no source previews found")
+	}
+	merged := image.NewNRGBA(image.Rect(0, 0, generatedPreviewWidth, generatedPreviewHeight))
+	for index, preview := range previews {
+		stripBounds := image.Rect(index * generatedPreviewWidth / len(previews), 0, (index + 1) * generatedPreviewWidth / len(previews), generatedPreviewHeight)
+		draw.Draw(merged, stripBounds, preview, stripBounds.Min, draw.Src)
+	}
+	return SavePNG(merged, destinationPath)
+}
+
+func saveThemeRecipe(themePath string, recipe models.ThemeRecipe) error {
+	data, err := json.MarshalIndent(recipe, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(themePath, themeRecipeName), data, defaultFilePerm)
+}
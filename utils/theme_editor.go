@@ -0,0 +1,260 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"nextui-aesthetics/models"
+)
+
+const newThemeBaseName = "New Theme"
+
+// coverageGroupHomes gives the component home directory theme image names are relative to for each target group
+var coverageGroupHomes = map[string]string{
+	CoverageGroupConsoles:		GetRomDirectory(),
+	CoverageGroupCollections:	GetCollectionDirectory(),
+	CoverageGroupTools:			ToolsDirectory,
+}
+
+// GetThemeSlots lists the slots for every target the coverage report knows, or for the folders nested inside the
+// given folder slot. Each target gets one slot per component type it can use
+func GetThemeSlots(theme models.Theme, folder models.ThemeSlot) ([]models.ThemeSlot, error) {
+	validParents, err := collectAllRomParents()
+	if err != nil {
+		return nil, err
+	}
+	components := GetThemeComponents(theme)
+
+	var slots []models.ThemeSlot
+	if folder.TargetPath == "" {
+		targets, err := collectCoverageTargets()
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range targets {
+			for _, componentType := range CoverageComponentTypes {
+				if _, applicable := target.row.Coverage[componentType]; !applicable {
+					continue
+				}
+				slot := models.ThemeSlot{
+					TargetName:		target.row.TargetName,
+					TargetGroup:	target.row.TargetGroup,
+					TargetPath:		target.path,
+					ParentPath:		target.parentPath,
+					ComponentType:	componentType,
+				}
+				if resolveThemeSlot(theme, components, &slot, validParents) {
+					slots = append(slots, slot)
+				}
+			}
+		}
+		return slots, nil
+	}
+
+	// Nested folders follow the same traversal rules as saving a theme
+	homeDirectory := coverageGroupHomes[folder.TargetGroup]
+	files, err := GetFileList(folder.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		itemName := file.Name()
+		if !file.IsDir() || strings.HasPrefix(itemName, ".") || !shouldDescendInto(TraversalScopeTheme, homeDirectory, folder.TargetPath, itemName) {
+			continue
+		}
+		for _, componentType := range CoverageComponentTypes {
+			slot := models.ThemeSlot{
+				TargetName:		itemName,
+				TargetGroup:	folder.TargetGroup,
+				TargetPath:		filepath.Join(folder.TargetPath, itemName),
+				ParentPath:		folder.TargetPath,
+				ComponentType:	componentType,
+			}
+			if resolveThemeSlot(theme, components, &slot, validParents) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots, nil
+}
+
+// HasNestedThemeSlots reports whether a slot's target is a folder that can hold decorated folders of its own
+func HasNestedThemeSlots(slot models.ThemeSlot) bool {
+	if slot.TargetGroup == CoverageGroupMenus {
+		return false
+	}
+	info, err := os.Stat(slot.TargetPath)
+	return err == nil && info.IsDir()
+}
+
+// SetThemeSlotImage copies a decoration into the theme as the slot's image. Picking the slot's own image is a no-op,
+// since copying a file onto itself would empty it
+func SetThemeSlotImage(slot models.ThemeSlot, sourcePath string) error {
+	if isThemeSlotSource(slot, sourcePath) {
+		return nil
+	}
+	return CopyFile(sourcePath, slot.SlotPath)
+}
+
+func isThemeSlotSource(slot models.ThemeSlot, sourcePath string) bool {
+	return filepath.Clean(sourcePath) == filepath.Clean(slot.SlotPath)
+}
+
+// DropSelfAssignments removes assignments that would copy a slot's own image back onto it
+func DropSelfAssignments(assignments []models.ThemeSlotAssignment) []models.ThemeSlotAssignment {
+	var keptAssignments []models.ThemeSlotAssignment
+	for _, assignment := range assignments {
+		if !isThemeSlotSource(assignment.Slot, assignment.SourcePath) {
+			keptAssignments = append(keptAssignments, assignment)
+		}
+	}
+	return keptAssignments
+}
+
+// ClearThemeSlot removes the slot's image from the theme
+func ClearThemeSlot(slot models.ThemeSlot) error {
+	if slot.ImagePath == "" {
+		return nil
+	}
+	return os.Remove(slot.ImagePath)
+}
+
+// AddDecorationsToTheme copies each decoration into its slot in order, so a later image for the same slot wins.
+// Decorations already sitting in their slot are skipped
+func AddDecorationsToTheme(assignments []models.ThemeSlotAssignment) (int, error) {
+	addCount := 0
+	for _, assignment := range DropSelfAssignments(assignments) {
+		if err := SetThemeSlotImage(assignment.Slot, assignment.SourcePath); err != nil {
+			return addCount, err
+		}
+		addCount++
+	}
+	return addCount, nil
+}
+
+// OverwrittenThemeSlots lists the theme images an add would replace, relative to the theme folder. Slots assigned more
+// than once are listed too, since only the last image assigned is kept
+func OverwrittenThemeSlots(theme models.Theme, assignments []models.ThemeSlotAssignment) []string {
+	var overwritten []string
+	assigned := make(map[string]bool)
+	listed := make(map[string]bool)
+	for _, assignment := range assignments {
+		slotPath := assignment.Slot.SlotPath
+		if (assignment.Slot.ImagePath != "" || assigned[slotPath]) && !listed[slotPath] {
+			relativePath, err := filepath.Rel(theme.ThemePath, slotPath)
+			if err != nil {
+				relativePath = slotPath
+			}
+			overwritten = append(overwritten, relativePath)
+			listed[slotPath] = true
+		}
+		assigned[slotPath] = true
+	}
+	return overwritten
+}
+
+// SuggestThemeSlot picks the slot a decoration most likely belongs in: its console and image class when both are
+// known, then the first slot of its image class
+func SuggestThemeSlot(slots []models.ThemeSlot, decoration models.Decoration) int {
+	consoleTag := FindConsoleTag(decoration.ConsoleName)
+	classIndex := -1
+	for index, slot := range slots {
+		if decoration.ImageClass != ImageClassUnknown && slot.ComponentType != decoration.ImageClass {
+			continue
+		}
+		if consoleTag != "" && FindConsoleTag(slot.TargetName) == consoleTag {
+			return index
+		}
+		if classIndex == -1 {
+			classIndex = index
+		}
+	}
+	if classIndex == -1 {
+		return 0
+	}
+	return classIndex
+}
+
+// CreateEmptyTheme makes a new theme folder for the editor to fill in
+func CreateEmptyTheme() (models.Theme, error) {
+	themeName, err := generateVariantThemeName(newThemeBaseName)
+	if err != nil {
+		return models.Theme{}, err
+	}
+	themePath := filepath.Join(ThemesDirectory, themeName)
+	if err := EnsureDirectoryExists(themePath); err != nil {
+		return models.Theme{}, err
+	}
+	return models.Theme{
+		ThemeName:	themeName,
+		ThemePath:	themePath,
+	}, nil
+}
+
+// resolveThemeSlot fills in the component, theme file, and current image for a slot. Slots whose target cannot be
+// named inside a theme, such as a console directory without a tag, are rejected
+func resolveThemeSlot(theme models.Theme, components []models.Component, slot *models.ThemeSlot, validParents map[string][]string) bool {
+	slot.ComponentName = coverageComponentNames[slot.TargetGroup][slot.ComponentType]
+	itemName := themeSlotFileName(*slot, validParents)
+	if slot.ComponentName == "" || itemName == "" {
+		return false
+	}
+
+	// Images already in the theme are edited where they are. Rom images may be shared by every directory for a console
+	var candidateNames []string
+	candidateNames = append(candidateNames, itemName)
+	if slot.TargetGroup == CoverageGroupConsoles {
+		itemParts := strings.Split(strings.TrimSuffix(itemName, ".png"), folderDelimiter)
+		if consoleTag := FindConsoleTag(itemParts[0]); consoleTag != itemParts[0] {
+			itemParts[0] = consoleTag
+			candidateNames = append(candidateNames, strings.Join(itemParts, folderDelimiter) + ".png")
+		}
+	}
+	slotDirectory := filepath.Join(theme.ThemePath, slot.ComponentName)
+	for _, component := range components {
+		if !composeComponentMatches(component, slot.ComponentName) {
+			continue
+		}
+		for _, componentPath := range component.ComponentPaths {
+			if component.ComponentName == slot.ComponentName && slotDirectory == filepath.Join(theme.ThemePath, slot.ComponentName) {
+				slotDirectory = componentPath
+			}
+			for _, candidateName := range candidateNames {
+				if slot.ImagePath == "" && DoesFileExists(filepath.Join(componentPath, candidateName)) {
+					slot.ImagePath = filepath.Join(componentPath, candidateName)
+				}
+			}
+		}
+	}
+	slot.SlotPath = slot.ImagePath
+	if slot.SlotPath == "" {
+		slot.SlotPath = filepath.Join(slotDirectory, itemName)
+	}
+	return true
+}
+
+// themeSlotFileName names a slot's image the way saving the current theme would: meta names for system menus, and the
+// target's path below its home directory joined by the folder delimiter otherwise, with rom directories as console tags
+func themeSlotFileName(slot models.ThemeSlot, validParents map[string][]string) string {
+	if slot.TargetGroup == CoverageGroupMenus {
+		for metaName, metaTarget := range themeMetaFileTargets {
+			if metaTarget == slot.TargetName {
+				return metaName
+			}
+		}
+		return ""
+	}
+	homeDirectory := coverageGroupHomes[slot.TargetGroup]
+	pathParts := strings.Split(strings.TrimPrefix(slot.TargetPath, homeDirectory + string(filepath.Separator)), string(filepath.Separator))
+	if slot.ComponentType == ComponentTypeIcon {
+		pathParts[len(pathParts) - 1] = GetSimpleFileName(pathParts[len(pathParts) - 1])
+	}
+	if slot.TargetGroup == CoverageGroupConsoles {
+		pathParts[0] = genNumberedConsoleTag(pathParts[0], validParents)
+		if pathParts[0] == "" {
+			return ""
+		}
+	}
+	return strings.Join(pathParts, folderDelimiter) + ".png"
+}
@@ -0,0 +1,216 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io/fs"
+	"nextui-aesthetics/models"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+)
+
+const (
+	RecolorModeHueShift	= "Hue Shift"
+	RecolorModeTint		= "Tint"
+	RecolorModePalette	= "Palette"
+	generatedPreviewWidth	= 640
+	generatedPreviewHeight	= 480
+)
+
+var RecolorTintNames = []string{"Red", "Orange", "Yellow", "Green", "Teal", "Blue", "Purple", "Pink"}
+
+var recolorTints = map[string]color.NRGBA{
+	"Red":		{R: 220, G: 40, B: 40, A: 255},
+	"Orange":	{R: 240, G: 130, B: 30, A: 255},
+	"Yellow":	{R: 235, G: 205, B: 40, A: 255},
+	"Green":	{R: 50, G: 180, B: 70, A: 255},
+	"Teal":		{R: 30, G: 170, B: 165, A: 255},
+	"Blue":		{R: 40, G: 90, B: 220, A: 255},
+	"Purple":	{R: 140, G: 60, B: 200, A: 255},
+	"Pink":		{R: 230, G: 90, B: 170, A: 255},
+}
+
+var RecolorPaletteNames = []string{"Grayscale", "Sepia", "Game Boy", "Virtual Boy", "Midnight"}
+
+// Palettes are ordered darkest to lightest for luminance remapping
+var recolorPalettes = map[string][]color.NRGBA{
+	"Grayscale": {
+		{R: 0, G: 0, B: 0, A: 255},
+		{R: 85, G: 85, B: 85, A: 255},
+		{R: 170, G: 170, B: 170, A: 255},
+		{R: 255, G: 255, B: 255, A: 255},
+	},
+	"Sepia": {
+		{R: 43, G: 28, B: 16, A: 255},
+		{R: 112, G: 78, B: 46, A: 255},
+		{R: 181, G: 141, B: 96, A: 255},
+		{R: 240, G: 222, B: 185, A: 255},
+	},
+	"Game Boy": {
+		{R: 15, G: 56, B: 15, A: 255},
+		{R: 48, G: 98, B: 48, A: 255},
+		{R: 139, G: 172, B: 15, A: 255},
+		{R: 155, G: 188, B: 15, A: 255},
+	},
+	"Virtual Boy": {
+		{R: 0, G: 0, B: 0, A: 255},
+		{R: 85, G: 0, B: 0, A: 255},
+		{R: 170, G: 0, B: 0, A: 255},
+		{R: 255, G: 0, B: 0, A: 255},
+	},
+	"Midnight": {
+		{R: 8, G: 10, B: 28, A: 255},
+		{R: 30, G: 40, B: 90, A: 255},
+		{R: 80, G: 110, B: 180, A: 255},
+		{R: 200, G: 220, B: 255, A: 255},
+	},
+}
+
+func RecolorTheme(theme models.Theme, selections models.RecolorSelections) (models.Theme, int, error) {
+	logger := common.GetLoggerInstance()
+	recolorCount := 0
+
+	transform, err := genRecolorTransform(selections)
+	if err != nil {
+		return models.Theme{}, recolorCount, err
+	}
+
+	variantName, err := generateVariantThemeName(theme.ThemeName + " (" + describeRecolor(selections) + ")")
+	if err != nil {
+		return models.Theme{}, recolorCount, err
+	}
+	variantPath := filepath.Join(ThemesDirectory, variantName)
+
+	// Walk the source theme, recoloring images and carrying everything else across untouched
+	previewWritten := false
+	var firstWallpaper string
+	err = filepath.WalkDir(theme.ThemePath, func(sourcePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(theme.ThemePath, sourcePath)
+		if err != nil {
+			return err
+		}
+		destinationPath := filepath.Join(variantPath, relativePath)
+		if entry.IsDir() {
+			return EnsureDirectoryExists(destinationPath)
+		}
+		itemName := entry.Name()
+		if filepath.Ext(itemName) != ".png" {
+			return CopyFile(sourcePath, destinationPath)
+		}
+
+		// Hidden previews become visible previews for the new variant
+		if itemName == previewStandardName || itemName == previewHiddenName {
+			if previewWritten {
+				return nil
+			}
+			destinationPath = filepath.Join(variantPath, previewStandardName)
+			previewWritten = true
+		} else if firstWallpaper == "" && strings.Contains(filepath.Base(filepath.Dir(sourcePath)), "Wallpapers") {
+			firstWallpaper = destinationPath
+		}
+
+		img, err := LoadImage(sourcePath)
+		if err != nil {
+			// Unreadable images are copied as-is rather than failing the whole variant
+			logger.Error("Unable to recolor image, copying instead", zap.String("path", sourcePath), zap.Error(err))
+			return CopyFile(sourcePath, destinationPath)
+		}
+		if err := SavePNG(transform(img), destinationPath); err != nil {
+			return err
+		}
+		recolorCount++
+		return nil
+	})
+	if err != nil {
+		// A half written variant would otherwise show up as a theme and a decoration source
+		os.RemoveAll(variantPath)
+		return models.Theme{}, recolorCount, err
+	}
+
+	// Themes built by hand often lack a preview. Build one from a recolored wallpaper so the variant is recognizable
+	if !previewWritten && firstWallpaper != "" {
+		if err := generatePreviewFromImage(firstWallpaper, filepath.Join(variantPath, previewStandardName)); err != nil {
+			logger.Error("Unable to generate variant preview", zap.Error(err))
+		}
+	}
+
+	return models.Theme{
+		ThemeName:		variantName,
+		ThemePath:		variantPath,
+		PreviewFound:	DoesFileExists(filepath.Join(variantPath, previewStandardName)),
+		ContainsTheme:	true,
+	}, recolorCount, nil
+}
+
+func genRecolorTransform(selections models.RecolorSelections) (func(image.Image) image.Image, error) {
+	switch selections.Mode {
+		case RecolorModeHueShift:
+			return func(img image.Image) image.Image {
+				return HueShiftImage(img, float64(selections.HueShift))
+			}, nil
+		case RecolorModeTint:
+			tint, exists := recolorTints[selections.TintName]
+			if !exists {
+				return nil, fmt.Errorf("unknown tint %s", selections.TintName)
+			}
+			return func(img image.Image) image.Image {
+				return TintImage(img, tint, float64(selections.TintStrength)/100)
+			}, nil
+		case RecolorModePalette:
+			palette, exists := recolorPalettes[selections.PaletteName]
+			if !exists {
+				return nil, fmt.Errorf("unknown palette %s", selections.PaletteName)
+			}
+			return func(img image.Image) image.Image {
+				return PaletteRemapImage(img, palette)
+			}, nil
+	}
+	return nil, fmt.Errorf("unknown recolor mode %s", selections.Mode)
+}
+
+func describeRecolor(selections models.RecolorSelections) string {
+	switch selections.Mode {
+		case RecolorModeHueShift:
+			return "Hue +" + strconv.Itoa(selections.HueShift)
+		case RecolorModeTint:
+			return selections.TintName + " Tint"
+	}
+	return selections.PaletteName
+}
+
+func generatePreviewFromImage(sourcePath string, destinationPath string) error {
+	img, err := LoadImage(sourcePath)
+	if err != nil {
+		return err
+	}
+	return SavePNG(ScaleImage(img, generatedPreviewWidth, generatedPreviewHeight), destinationPath)
+}
+
+func generateVariantThemeName(baseName string) (string, error) {
+	attemptNumber := 1
+	for {
+		var suffix string
+		if attemptNumber == 1 {
+			suffix = ""
+		} else {
+			suffix = " (" + strconv.Itoa(attemptNumber) + ")"
+		}
+		if !DoesFileExists(filepath.Join(ThemesDirectory, baseName + suffix)) {
+			return baseName + suffix, nil
+		}
+		if attemptNumber > 10 {
+			return "", errors.New("No valid theme names available")
+		}
+		attemptNumber++
+	}
+}
@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+
+	"nextui-aesthetics/models"
+)
+
+const (
+	RotationDaily		= "Daily"
+	RotationEveryBoot	= "Every Boot"
+	RotationTimeOfDay	= "Time of Day"
+)
+
+var RotationPolicies = []string{RotationDaily, RotationEveryBoot, RotationTimeOfDay}
+
+var themeRotationPath = filepath.Join(AestheticsDirectory, "rotation.json")
+
+// LoadThemeRotation reads the rotation list and state, starting an empty daily rotation when none is saved
+func LoadThemeRotation() models.ThemeRotation {
+	rotation := models.ThemeRotation{
+		Policy:			RotationDaily,
+		AppliedIndex:	-1,
+	}
+	data, err := os.ReadFile(themeRotationPath)
+	if err != nil {
+		return rotation
+	}
+	if err := json.Unmarshal(data, &rotation); err != nil {
+		common.GetLoggerInstance().Error("Unable to read theme rotation", zap.Error(err))
+		return models.ThemeRotation{
+			Policy:			RotationDaily,
+			AppliedIndex:	-1,
+		}
+	}
+	return rotation
+}
+
+func SaveThemeRotation(rotation models.ThemeRotation) error {
+	data, err := json.MarshalIndent(rotation, "", "  ")
+	if err != nil {
+		return err
+	}
+	EnsureDirectoryExists(filepath.Dir(themeRotationPath))
+	return os.WriteFile(themeRotationPath, data, defaultFilePerm)
+}
+
+// NextRotationPolicy returns the policy after the given one, wrapping back to the first
+func NextRotationPolicy(policy string) string {
+	for position, rotationPolicy := range RotationPolicies {
+		if rotationPolicy == policy {
+			return RotationPolicies[(position + 1) % len(RotationPolicies)]
+		}
+	}
+	return RotationPolicies[0]
+}
+
+// DescribeRotationEntry names a rotation entry for lists and the rotate command's output
+func DescribeRotationEntry(entry models.RotationEntry) string {
+	if entry.Shuffle == nil {
+		return entry.ThemeName
+	}
+	description := "Shuffle " + strings.Join(entry.Shuffle.ComponentTypes, " + ")
+	if entry.Shuffle.Seed != 0 {
+		description = fmt.Sprintf("%s (Seed %d)", description, entry.Shuffle.Seed)
+	}
+	return description
+}
+
+// RunThemeRotation applies the rotation entry that is due, if any, without asking anything on screen. It is meant for
+// the rotate command run at boot. The rotation moves on even when an entry fails, so one missing theme cannot stall it
+func RunThemeRotation(sources []models.DecorationSource, now time.Time) (string, error) {
+	rotation := LoadThemeRotation()
+	if len(rotation.Entries) == 0 {
+		return "No themes in the rotation", nil
+	}
+	index, due := dueRotationIndex(rotation, now)
+	if !due {
+		return "Rotation is not due", nil
+	}
+
+	description, applyErr := applyRotationEntry(rotation.Entries[index], sources)
+	rotation.AppliedIndex = index
+	rotation.NextIndex = (index + 1) % len(rotation.Entries)
+	rotation.LastRotated = now.Unix()
+	if err := SaveThemeRotation(rotation); err != nil && applyErr == nil {
+		return description, err
+	}
+	return description, applyErr
+}
+
+// dueRotationIndex picks the entry the policy calls for and whether it should be applied now
+func dueRotationIndex(rotation models.ThemeRotation, now time.Time) (int, bool) {
+	switch rotation.Policy {
+		case RotationTimeOfDay:
+			index := rotationIndexForHour(rotation.Entries, now.Hour())
+			return index, index != rotation.AppliedIndex
+		case RotationDaily:
+			lastRotated := time.Unix(rotation.LastRotated, 0)
+			if rotation.LastRotated != 0 && lastRotated.YearDay() == now.YearDay() && lastRotated.Year() == now.Year() {
+				return 0, false
+			}
+	}
+	return rotation.NextIndex % len(rotation.Entries), true
+}
+
+// rotationIndexForHour finds the entry with the latest start at or before the hour. Before the earliest start, the
+// latest entry of the previous day is still in effect
+func rotationIndexForHour(entries []models.RotationEntry, hour int) int {
+	currentIndex := -1
+	latestIndex := 0
+	for index, entry := range entries {
+		if entry.StartHour <= hour && (currentIndex == -1 || entry.StartHour >= entries[currentIndex].StartHour) {
+			currentIndex = index
+		}
+		if entry.StartHour >= entries[latestIndex].StartHour {
+			latestIndex = index
+		}
+	}
+	if currentIndex == -1 {
+		return latestIndex
+	}
+	return currentIndex
+}
+
+// applyRotationEntry applies a theme with every component and target, or runs a shuffle, with no prompts
+func applyRotationEntry(entry models.RotationEntry, sources []models.DecorationSource) (string, error) {
+	if entry.Shuffle != nil {
+		selections := *entry.Shuffle
+		if selections.Seed == 0 {
+			selections.Seed = NewShuffleSeed()
+		}
+		consoleAggregation, _ := GenerateDecorationAggregations(sources, &DecorationScanProgress{})
+		plan, lockedDecorations, err := PlanShuffle(consoleAggregation, selections)
+		if err != nil {
+			return "", err
+		}
+		applyCount, err := ApplyDecorationPlan(plan, CopyFile)
+		return fmt.Sprintf("Shuffled %d decorations with seed %d, %d locked", applyCount, selections.Seed, len(lockedDecorations)), err
+	}
+
+	theme, exists := GetDownloadedThemes()[entry.ThemeName]
+	if !exists {
+		return "", fmt.Errorf("theme %s is no longer installed", entry.ThemeName)
+	}
+	update := newDecorationUpdate()
+	defer update.finish()
+	modifyCount, err := applySelectedThemeComponents(theme, GetThemeComponents(theme), models.ComponentOptionSelections{OptionAll: true}, update)
+	report := update.report(modifyCount)
+	return fmt.Sprintf("Applied %s: %d changed, %d unchanged, %d locked", theme.ThemeName, report.ModifyCount, report.UnchangedCount, len(report.LockedDecorations)), err
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"go.uber.org/atomic"
+	"nextui-aesthetics/models"
+)
+
+const themeUpdateRefreshDelay = 1 * time.Second
+
+// ThemeUpdateProgress is shared between a running theme apply, clear, or save and the screen waiting on it.
+// Cancelling takes effect before the next file, so the file in progress is always finished first
+type ThemeUpdateProgress struct {
+	FilesDone		atomic.Int64
+	FilesTotal		atomic.Int64
+	currentTarget	atomic.String
+	cancelled		atomic.Bool
+}
+
+func (progress *ThemeUpdateProgress) Cancel() {
+	progress.cancelled.Store(true)
+}
+
+func (progress *ThemeUpdateProgress) IsCancelled() bool {
+	return progress.cancelled.Load()
+}
+
+// Fraction reports files done against the estimated total, which can run slightly high for filtered folders
+func (progress *ThemeUpdateProgress) Fraction() float64 {
+	total := progress.FilesTotal.Load()
+	if total <= 0 {
+		return 0
+	}
+	return min(1, float64(progress.FilesDone.Load()) / float64(total))
+}
+
+func (progress *ThemeUpdateProgress) CurrentTarget() string {
+	return progress.currentTarget.Load()
+}
+
+// startStep resets the counters for the next apply, clear, or save pass
+func (progress *ThemeUpdateProgress) startStep(filesTotal int64) {
+	progress.FilesDone.Store(0)
+	progress.FilesTotal.Store(filesTotal)
+	progress.currentTarget.Store("")
+}
+
+// describeUpdateTarget names the console, collection, tool, or menu a decoration file belongs to
+func describeUpdateTarget(filePath string) string {
+	if metaTarget, isMeta := metaPathTargets[filePath]; isMeta {
+		return metaTarget
+	}
+	for _, homeDirectory := range []string{GetRomDirectory(), GetCollectionDirectory(), ToolsDirectory} {
+		if !strings.HasPrefix(filePath, homeDirectory + "/") {
+			continue
+		}
+		firstPart := strings.Split(strings.TrimPrefix(filePath, homeDirectory + "/"), string(filepath.Separator))[0]
+		if firstPart == ".media" {
+			// Folder icons sit in the parent media folder, named after their folder
+			return GetSimpleFileName(filePath)
+		}
+		return GetSimpleFileName(firstPart)
+	}
+	return strings.TrimPrefix(filePath, SDCardDirectory + "/")
+}
+
+// runThemeUpdateStep runs one pass in the background while showing files done and the current target. Pressing B
+// cancels the pass once the file in progress is finished
+func runThemeUpdateStep(message string, update *decorationUpdate, step func() (int, error)) (int, error) {
+	var count int
+	var err error
+	stepFinished := make(chan struct{})
+	go func() {
+		count, err = step()
+		close(stepFinished)
+	}()
+
+	progress := update.progress
+	progressBar := &atomic.Float64{}
+	stopWatching := WatchForBackButton(progress.Cancel)
+	defer stopWatching()
+	for {
+		progressMessage := fmt.Sprintf("%s\n%d of %d files", message, progress.FilesDone.Load(), progress.FilesTotal.Load())
+		if progress.IsCancelled() {
+			progressMessage = progressMessage + "\nCancelling after the current file"
+		} else if currentTarget := progress.CurrentTarget(); currentTarget != "" {
+			progressMessage = progressMessage + "\n" + currentTarget + "\nPress B to cancel"
+		} else {
+			progressMessage = progressMessage + "\nPress B to cancel"
+		}
+		gaba.ProcessMessage(progressMessage, gaba.ProcessMessageOptions{ShowProgressBar: true, Progress: progressBar}, func() (interface{}, error) {
+			progressBar.Store(progress.Fraction())
+			select {
+				case <-stepFinished:
+				case <-time.After(themeUpdateRefreshDelay):
+			}
+			progressBar.Store(progress.Fraction())
+			return nil, nil
+		})
+		select {
+			case <-stepFinished:
+				return count, err
+			default:
+		}
+	}
+}
+
+// countThemeComponentFiles counts the images an apply pass will look at
+func countThemeComponentFiles(components []models.Component) int64 {
+	var filesTotal int64
+	for _, component := range components {
+		for _, componentPath := range component.ComponentPaths {
+			files, err := GetFileList(componentPath)
+			if err != nil {
+				continue
+			}
+			for _, file := range files {
+				if filepath.Ext(file.Name()) == ".png" {
+					filesTotal++
+				}
+			}
+		}
+	}
+	return filesTotal
+}
+
+// countDeviceDecorationFiles estimates the images a clear or save pass will look at. Console filters are not
+// applied, so the estimate can only run high
+func countDeviceDecorationFiles(components []models.Component) int64 {
+	var filesTotal int64
+	homeDirectories := make(map[string]bool)
+	for _, component := range components {
+		if component.ComponentType.ContainsMetaFiles {
+			filesTotal = filesTotal + int64(len(themeMetaFileDestinations[component.ComponentType.ComponentType]))
+		}
+		homeDirectories[component.ComponentType.ComponentHomeDirectory] = true
+	}
+	for homeDirectory := range homeDirectories {
+		filesTotal = filesTotal + countMediaDecorationFiles(homeDirectory, homeDirectory)
+	}
+	return filesTotal
+}
+
+func countMediaDecorationFiles(currentPath string, homeDirectory string) int64 {
+	var filesTotal int64
+	files, err := GetFileList(currentPath)
+	if err != nil {
+		return 0
+	}
+	isMedia := filepath.Base(currentPath) == ".media"
+	for _, file := range files {
+		itemName := file.Name()
+		if isMedia {
+			if filepath.Ext(itemName) == ".png" {
+				filesTotal++
+			}
+		} else if file.IsDir() && shouldDescendInto(TraversalScopeTheme, homeDirectory, currentPath, itemName) {
+			filesTotal = filesTotal + countMediaDecorationFiles(filepath.Join(currentPath, itemName), homeDirectory)
+		}
+	}
+	return filesTotal
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"nextui-aesthetics/models"
+)
+
+// userTraversalRules is set once from the config at startup, before any walk can read it
+var userTraversalRules []models.TraversalRule
+
+func SetUserTraversalRules(rules []models.TraversalRule) {
+	userTraversalRules = rules
+}
+
+// shouldDescendInto decides whether a walk of the given scope should enter childName inside parentPath.
+// A leaf parent only lets the walk into its .media folder, and an ignored child is never entered
+func shouldDescendInto(scope string, rootPath string, parentPath string, childName string) bool {
+	parentAction := traversalActionFor(scope, rootPath, parentPath)
+	if parentAction == TraversalIgnore || (parentAction == TraversalLeaf && childName != ".media") {
+		return false
+	}
+	return traversalActionFor(scope, rootPath, filepath.Join(parentPath, childName)) != TraversalIgnore
+}
+
+// traversalActionFor returns the action of the first matching rule, descending when nothing matches
+func traversalActionFor(scope string, rootPath string, directoryPath string) string {
+	for _, rules := range [][]models.TraversalRule{userTraversalRules, DefaultTraversalRules} {
+		for _, rule := range rules {
+			if traversalRuleMatches(rule, scope, rootPath, directoryPath) {
+				return rule.Action
+			}
+		}
+	}
+	return TraversalDescend
+}
+
+func traversalRuleMatches(rule models.TraversalRule, scope string, rootPath string, directoryPath string) bool {
+	if rule.Scope != "" && rule.Scope != scope {
+		return false
+	}
+	depthRoot := rootPath
+	if rule.PathPrefix != "" {
+		if directoryPath != rule.PathPrefix && !strings.HasPrefix(directoryPath, rule.PathPrefix + "/") {
+			return false
+		}
+		depthRoot = rule.PathPrefix
+	}
+	depth := directoryDepth(depthRoot, directoryPath)
+	if depth < rule.MinDepth || (rule.MaxDepth > 0 && depth > rule.MaxDepth) {
+		return false
+	}
+	if rule.Tag != "" && FindConsoleTag(directoryPath) != rule.Tag {
+		return false
+	}
+	return true
+}
+
+func directoryDepth(rootPath string, directoryPath string) int {
+	relativePath, err := filepath.Rel(rootPath, directoryPath)
+	if err != nil || relativePath == "." || strings.HasPrefix(relativePath, "..") {
+		return 0
+	}
+	return strings.Count(relativePath, "/") + 1
+}
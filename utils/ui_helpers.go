@@ -2,6 +2,7 @@ package utils
 
 import (
 	gaba "github.com/redria7/gabagool/pkg/gabagool"
+	"github.com/veandco/go-sdl2/sdl"
 	"time"
 )
 
@@ -12,6 +13,21 @@ func ShowTimedMessage(message string, delay time.Duration) {
 	})
 }
 
+// WatchForBackButton calls cancel when B is pressed, even while a progress message is reading the input.
+// The returned function stops the watch
+func WatchForBackButton(cancel func()) func() {
+	processor := gaba.GetInputProcessor()
+	handle := sdl.AddEventWatchFunc(func(event sdl.Event, _ interface{}) bool {
+		if inputEvent := processor.ProcessSDLEvent(event); inputEvent != nil && inputEvent.Pressed && inputEvent.Button == gaba.ButtonB {
+			cancel()
+		}
+		return true
+	}, nil)
+	return func() {
+		sdl.DelEventWatch(handle)
+	}
+}
+
 func ConfirmAction(message string, imagePath string) bool {
 	result, err := gaba.ConfirmationMessage(message, []gaba.FooterHelpItem{
 		{ButtonName: "B", HelpText: "I Changed My Mind"},